@@ -23,7 +23,7 @@ func main() {
 		log.Fatal("Error loading config %w", err)
 	}
 
-	logger, err := logs.NewLoggerWithService(config.Monitor)
+	logger, logOutput, err := logs.NewLoggerWithService(config.Monitor)
 	if err != nil {
 		log.Fatal("Error setup logger %w", err)
 	}
@@ -33,11 +33,20 @@ func main() {
 		log.Fatal("Error creating new server %w", err)
 	}
 
-	sr := service.New(s)
+	sr, err := service.New(s)
+	if err != nil {
+		log.Fatal("Error creating services %w", err)
+	}
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go sr.StudentService.RunReencryption(jobsCtx)
+	go logs.WatchLevels(jobsCtx, config.Monitor.Logging.LevelsFile)
+	go s.Health.Run(jobsCtx)
+
 	// Handler setup
 	h := handler.New(s, sr)
 	// Router setup
-	r := router.NewRouter(s, h)
+	r := router.NewRouter(s, h, sr.AuthService.MockIdP())
 
 	stopChan := make(chan os.Signal, 1)
 	errChan := make(chan error, 1)
@@ -52,6 +61,7 @@ func main() {
 
 	select {
 	case <-stopChan:
+		stopJobs()
 		log.Printf("Stopping server in %d sec \n", int(CleaningTime.Seconds()))
 		ctx, cancel := context.WithTimeout(context.Background(), CleaningTime)
 		defer cancel()
@@ -59,6 +69,7 @@ func main() {
 		if err := s.Stop(ctx); err != nil {
 			errChan <- err
 		}
+		_ = logOutput.Close()
 
 		select {
 		case <-ctx.Done():
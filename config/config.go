@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator"
 	_ "github.com/joho/godotenv/autoload"
@@ -13,11 +14,16 @@ import (
 )
 
 type Config struct {
-	Primary  Primary        `koanf:"primary" validate:"required"`
-	Server   ServerConfig   `koanf:"server" validate:"required"`
-	Database DatabaseConfig `koanf:"database" validate:"required"`
-	Redis    RedisConfig    `koanf:"redis" validate:"required"`
-	Monitor  *Monitor       `koanf:"monitor" validate:"required"`
+	Primary    Primary          `koanf:"primary" validate:"required"`
+	Server     ServerConfig     `koanf:"server" validate:"required"`
+	Database   DatabaseConfig   `koanf:"database" validate:"required"`
+	Redis      RedisConfig      `koanf:"redis" validate:"required"`
+	Monitor    *Monitor         `koanf:"monitor" validate:"required"`
+	Tenancy    TenancyConfig    `koanf:"tenancy"`
+	Session    SessionConfig    `koanf:"session"`
+	OIDC       OIDCConfig       `koanf:"oidc"`
+	Encryption EncryptionConfig `koanf:"encryption"`
+	Webhooks   WebhookConfig    `koanf:"webhooks"`
 }
 
 type Primary struct {
@@ -26,11 +32,19 @@ type Primary struct {
 }
 
 type ServerConfig struct {
-	Port               string   `koanf:"port" validate:"required"`
-	ReadTimeout        int      `koanf:"read_timeout" validate:"required"`
-	WriteTimeout       int      `koanf:"write_timeout" validate:"required"`
-	IdleTimeout        int      `koanf:"idle_timeout" validate:"required"`
-	CORSAllowedOrigins []string `koanf:"cors_allowed_origins" validate:"required"`
+	Port               string          `koanf:"port" validate:"required"`
+	ReadTimeout        int             `koanf:"read_timeout" validate:"required"`
+	WriteTimeout       int             `koanf:"write_timeout" validate:"required"`
+	IdleTimeout        int             `koanf:"idle_timeout" validate:"required"`
+	CORSAllowedOrigins []string        `koanf:"cors_allowed_origins" validate:"required"`
+	IdempotencyTTL     int             `koanf:"idempotency_ttl"`
+	CORS               CORSConfig      `koanf:"cors"`
+	Security           SecurityConfig  `koanf:"security"`
+	Timeouts           TimeoutConfig   `koanf:"timeouts"`
+	Errors             ErrorsConfig    `koanf:"errors"`
+	RateLimit          RateLimitConfig `koanf:"rate_limit"`
+	Network            NetworkConfig   `koanf:"network"`
+	TLS                TLSConfig       `koanf:"tls"`
 }
 
 type DatabaseConfig struct {
@@ -48,6 +62,22 @@ type DatabaseConfig struct {
 
 type RedisConfig struct {
 	Address string `koanf:"address" validate:"required"`
+	// SlowCommandThreshold logs commands and pipelines that take longer,
+	// by name only.
+	SlowCommandThreshold time.Duration `koanf:"slow_command_threshold"`
+}
+
+func (c *RedisConfig) ApplyDefaults() {
+	if c.SlowCommandThreshold == 0 {
+		c.SlowCommandThreshold = 50 * time.Millisecond
+	}
+}
+
+func (c *RedisConfig) Validate() error {
+	if c.SlowCommandThreshold < 0 {
+		return fmt.Errorf("redis.slow_command_threshold must not be negative")
+	}
+	return nil
 }
 
 func LoadConfig() (*Config, error) {
@@ -71,10 +101,46 @@ func LoadConfig() (*Config, error) {
 		logger.Fatal().Err(err).Msg("could not unmarshal main config")
 	}
 
+	config.Server.ApplyDefaults(config.Primary)
+	if err := config.Server.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate server config")
+	}
+
+	config.Redis.ApplyDefaults()
+	if err := config.Redis.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate redis config")
+	}
+
+	config.Tenancy.ApplyDefaults()
+	if err := config.Tenancy.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate tenancy config")
+	}
+
+	config.Session.ApplyDefaults(config.Primary)
+	if err := config.Session.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate session config")
+	}
+
+	config.OIDC.ApplyDefaults(config.Server.Port)
+	if err := config.OIDC.Validate(config.Primary); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate oidc config")
+	}
+
+	config.Encryption.ApplyDefaults()
+	if err := config.Encryption.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate encryption config")
+	}
+
+	config.Webhooks.ApplyDefaults()
+	if err := config.Webhooks.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("could not validate webhook config")
+	}
+
 	if config.Monitor == nil {
 		config.Monitor = DefaultMonitorConfig()
 	}
 
+	config.Monitor.ApplyDefaults()
 	if err := config.Monitor.Validate(); err != nil {
 		logger.Fatal().Err(err).Msg("could not validate monitor")
 	}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type EncryptionConfig struct {
+	// Keys are "id:base64-key" pairs of 32 byte AES keys. The key derived from
+	// Primary.SecretKey is active without keys and otherwise kept to decrypt,
+	// so rows written before keys were configured stay readable until they
+	// are re-encrypted.
+	Keys []string `koanf:"keys"`
+	// ActiveKeyID encrypts new values, the other keys only decrypt.
+	ActiveKeyID string `koanf:"active_key_id"`
+	// BlindIndexKey is a base64 HMAC key for searchable fields. Changing it,
+	// setting it for the first time included, leaves existing indexes
+	// unmatched. Rotate ActiveKeyID along with it, re-encryption rewrites
+	// the indexes of every row it moves, search misses the rest until then.
+	BlindIndexKey string `koanf:"blind_index_key"`
+
+	// ReencryptInterval is how often rows under old keys are re-encrypted,
+	// zero disables the job.
+	ReencryptInterval  time.Duration `koanf:"reencrypt_interval"`
+	ReencryptBatchSize int           `koanf:"reencrypt_batch_size"`
+}
+
+func (c *EncryptionConfig) ApplyDefaults() {
+	if c.ReencryptInterval == 0 {
+		c.ReencryptInterval = 10 * time.Minute
+	}
+	if c.ReencryptBatchSize == 0 {
+		c.ReencryptBatchSize = 100
+	}
+}
+
+func (c *EncryptionConfig) Validate() error {
+	if len(c.Keys) > 0 && c.ActiveKeyID == "" {
+		return fmt.Errorf("encryption active_key_id is required when keys are set")
+	}
+
+	if c.ReencryptInterval < 0 || c.ReencryptBatchSize < 0 {
+		return fmt.Errorf("encryption reencrypt_interval and reencrypt_batch_size must be non-negative")
+	}
+
+	return nil
+}
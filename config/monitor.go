@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -11,23 +13,127 @@ type Monitor struct {
 	Logging      LoggingConfig      `koanf:"logging" validate:"required"`
 	OTEL         OTELConfig         `koanf:"otel_config" validate:"required"`
 	HealthChecks HealthChecksConfig `koanf:"health_checks" validate:"required"`
+	Metrics      MetricsConfig      `koanf:"metrics"`
 }
 
 type LoggingConfig struct {
 	Level              string        `koanf:"level" validate:"required"`
 	Format             string        `koanf:"format" validate:"required"`
 	SlowQueryThreshold time.Duration `koanf:"slow_query_threshold"`
+	// ExplainSlowQueries logs the EXPLAIN (ANALYZE, BUFFERS) plan of slow
+	// read-only queries. It runs them twice, development only.
+	ExplainSlowQueries bool            `koanf:"explain_slow_queries"`
+	Redaction          RedactionConfig `koanf:"redaction"`
+	// Outputs are stdout, stderr or file paths, every line goes to all of
+	// them in Format.
+	Outputs []string `koanf:"outputs"`
+	// Components sets levels of the http, database, cache and jobs loggers,
+	// the others follow Level. Levels can be changed at runtime through the
+	// admin API and on SIGUSR1, which restores these levels and then applies
+	// LevelsFile.
+	Components map[string]string `koanf:"components"`
+	LevelsFile string            `koanf:"levels_file"`
+	Rotation   LogRotationConfig `koanf:"rotation"`
+	Async      LogAsyncConfig    `koanf:"async"`
 }
 
+const (
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
+	LogFormatLogfmt  = "logfmt"
+
+	LogOutputStdout = "stdout"
+	LogOutputStderr = "stderr"
+)
+
+// LogRotationConfig applies to file outputs. A file is rotated when it grows
+// past MaxSizeMB, rotated files are gzipped and removed after MaxAge or once
+// more than MaxBackups exist.
+type LogRotationConfig struct {
+	MaxSizeMB          int           `koanf:"max_size_mb"`
+	MaxAge             time.Duration `koanf:"max_age"`
+	MaxBackups         int           `koanf:"max_backups"`
+	DisableCompression bool          `koanf:"disable_compression"`
+}
+
+// LogAsyncConfig buffers lines so logging never blocks a request. When the
+// outputs fall behind, lines are dropped and counted.
+type LogAsyncConfig struct {
+	Disabled     bool          `koanf:"disabled"`
+	BufferSize   int           `koanf:"buffer_size"`
+	PollInterval time.Duration `koanf:"poll_interval"`
+}
+
+// RedactionConfig scrubs personal data from log lines and span attributes
+// before they are written or exported. Values of fields whose name is listed
+// are replaced whole, patterns are replaced wherever they match in strings.
+type RedactionConfig struct {
+	// Disabled turns redaction off, it is on unless asked otherwise.
+	Disabled bool `koanf:"disabled"`
+	// Fields are matched against whole keys, case-insensitively and ignoring
+	// "_", "-" and ".", so guardian_contact also covers guardianContact. Keys
+	// of nested log objects are matched on their own.
+	Fields []string `koanf:"fields"`
+	// Patterns are regular expressions, RE2 syntax.
+	Patterns    []string `koanf:"patterns"`
+	Replacement string   `koanf:"replacement"`
+}
+
+const (
+	TraceExporterOTLPHTTP = "otlphttp"
+	TraceExporterOTLPGRPC = "otlpgrpc"
+	TraceExporterStdout   = "stdout"
+	TraceExporterNone     = "none"
+)
+
 type OTELConfig struct {
-	TempoEndpoint string `koanf:"tempo_endpoint" validate:"required"`
+	// TempoEndpoint is the collector the otlphttp and otlpgrpc exporters
+	// send to, the other exporters ignore it.
+	TempoEndpoint string `koanf:"tempo_endpoint"`
+	// MetricsInterval is how often metrics are pushed to the collector.
+	MetricsInterval time.Duration `koanf:"metrics_interval"`
+
+	// Exporter is otlphttp, otlpgrpc, stdout or none, for traces and metrics
+	// alike.
+	Exporter string `koanf:"exporter"`
+	// SampleRatio is the share of new traces recorded, unset uses 1 and 0
+	// records none. Requests continuing a trace follow the caller's decision.
+	SampleRatio *float64 `koanf:"sample_ratio"`
+
+	// Batcher settings, spans beyond MaxQueueSize are dropped rather than
+	// slowing requests down.
+	BatchTimeout       time.Duration `koanf:"batch_timeout"`
+	ExportTimeout      time.Duration `koanf:"export_timeout"`
+	MaxQueueSize       int           `koanf:"max_queue_size"`
+	MaxExportBatchSize int           `koanf:"max_export_batch_size"`
 }
 
+type MetricsConfig struct {
+	// Path serves the Prometheus registry, put it behind the "metrics"
+	// access list when the port is public.
+	Path      string `koanf:"path"`
+	Namespace string `koanf:"namespace"`
+	// DurationBuckets are the request latency histogram buckets in seconds.
+	DurationBuckets []float64 `koanf:"duration_buckets"`
+}
+
+const (
+	HealthCheckDatabase = "database"
+	HealthCheckRedis    = "redis"
+)
+
+// HealthChecksConfig drives the background checker behind /readyz. A check
+// slower than DegradedLatency, or failing fewer than FailureThreshold times
+// in a row, is degraded and keeps the service ready.
 type HealthChecksConfig struct {
 	Enabled  bool          `koanf:"enabled"`
 	Interval time.Duration `koanf:"interval"`
 	Timeout  time.Duration `koanf:"timeout"`
-	Checks   []string      `koanf:"checks"`
+	// Checks are database and redis, db, postgres and cache are accepted
+	// as aliases.
+	Checks           []string      `koanf:"checks"`
+	DegradedLatency  time.Duration `koanf:"degraded_latency"`
+	FailureThreshold int           `koanf:"failure_threshold"`
 }
 
 func DefaultMonitorConfig() *Monitor {
@@ -48,6 +154,117 @@ func DefaultMonitorConfig() *Monitor {
 	}
 }
 
+func (c *Monitor) ApplyDefaults() {
+	if c.HealthChecks.Interval == 0 {
+		c.HealthChecks.Interval = 30 * time.Second
+	}
+	if c.HealthChecks.Timeout == 0 {
+		c.HealthChecks.Timeout = 5 * time.Second
+	}
+	if len(c.HealthChecks.Checks) == 0 {
+		c.HealthChecks.Checks = []string{HealthCheckDatabase, HealthCheckRedis}
+	}
+	for i, check := range c.HealthChecks.Checks {
+		switch strings.ToLower(strings.TrimSpace(check)) {
+		case "db", "postgres", HealthCheckDatabase:
+			c.HealthChecks.Checks[i] = HealthCheckDatabase
+		case "cache", HealthCheckRedis:
+			c.HealthChecks.Checks[i] = HealthCheckRedis
+		}
+	}
+	if c.HealthChecks.DegradedLatency == 0 {
+		c.HealthChecks.DegradedLatency = time.Second
+	}
+	if c.HealthChecks.FailureThreshold == 0 {
+		c.HealthChecks.FailureThreshold = 3
+	}
+
+	if c.Logging.SlowQueryThreshold == 0 {
+		c.Logging.SlowQueryThreshold = 100 * time.Millisecond
+	}
+
+	if c.Logging.Format == "" {
+		c.Logging.Format = LogFormatJSON
+	}
+	// text is the earlier name of the console format
+	if c.Logging.Format == "text" {
+		c.Logging.Format = LogFormatConsole
+	}
+	if len(c.Logging.Outputs) == 0 {
+		c.Logging.Outputs = []string{"/var/lib/logs/app.log"}
+	}
+	if c.Logging.Rotation.MaxSizeMB == 0 {
+		c.Logging.Rotation.MaxSizeMB = 100
+	}
+	if c.Logging.Rotation.MaxAge == 0 {
+		c.Logging.Rotation.MaxAge = 7 * 24 * time.Hour
+	}
+	if c.Logging.Rotation.MaxBackups == 0 {
+		c.Logging.Rotation.MaxBackups = 10
+	}
+	if c.Logging.Async.BufferSize == 0 {
+		c.Logging.Async.BufferSize = 10000
+	}
+	if c.Logging.Async.PollInterval == 0 {
+		c.Logging.Async.PollInterval = 10 * time.Millisecond
+	}
+
+	redaction := &c.Logging.Redaction
+	if len(redaction.Fields) == 0 {
+		redaction.Fields = []string{
+			"name", "phone", "address", "guardian_contact", "email",
+			"password", "secret", "token", "authorization", "cookie", "set_cookie",
+			"client_secret", "code_verifier", "id_token", "access_token", "csrf_token",
+			// pgx query arguments carry whatever is written to the database
+			"args",
+		}
+	}
+	if len(redaction.Patterns) == 0 {
+		redaction.Patterns = []string{
+			// e-mail addresses
+			`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+			// Bangladeshi mobile numbers, with or without country code. The
+			// word boundaries keep digit runs inside trace and request ids.
+			`(?:\+88|\b(?:88)?)01[3-9]\d{8}\b`,
+			// international numbers in E.164 form
+			`\+[1-9]\d{7,14}\b`,
+		}
+	}
+	if redaction.Replacement == "" {
+		redaction.Replacement = "[REDACTED]"
+	}
+
+	if c.OTEL.MetricsInterval == 0 {
+		c.OTEL.MetricsInterval = 30 * time.Second
+	}
+	if c.OTEL.Exporter == "" {
+		c.OTEL.Exporter = TraceExporterOTLPHTTP
+	}
+	if c.OTEL.SampleRatio == nil {
+		sampleRatio := 1.0
+		c.OTEL.SampleRatio = &sampleRatio
+	}
+	if c.OTEL.BatchTimeout == 0 {
+		c.OTEL.BatchTimeout = 5 * time.Second
+	}
+	if c.OTEL.ExportTimeout == 0 {
+		c.OTEL.ExportTimeout = 30 * time.Second
+	}
+	if c.OTEL.MaxQueueSize == 0 {
+		c.OTEL.MaxQueueSize = 2048
+	}
+	if c.OTEL.MaxExportBatchSize == 0 {
+		c.OTEL.MaxExportBatchSize = 512
+	}
+
+	if c.Metrics.Path == "" {
+		c.Metrics.Path = "/metrics"
+	}
+	if len(c.Metrics.DurationBuckets) == 0 {
+		c.Metrics.DurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+	}
+}
+
 func (c *Monitor) Validate() error {
 	if c.ServiceName == "" {
 		return fmt.Errorf("service_name is required")
@@ -66,6 +283,69 @@ func (c *Monitor) Validate() error {
 		return fmt.Errorf("logging slow_query_threshold must be non-negative")
 	}
 
+	switch c.Logging.Format {
+	case LogFormatJSON, LogFormatConsole, LogFormatLogfmt:
+	default:
+		return fmt.Errorf("invalid logging format: %s (must be one of: json, console, logfmt)", c.Logging.Format)
+	}
+
+	for name, level := range c.Logging.Components {
+		if !validLevels[level] {
+			return fmt.Errorf("invalid logging level for component %s: %s (must be one of: debug, info, warn, error)", name, level)
+		}
+	}
+
+	for _, output := range c.Logging.Outputs {
+		if output == "" {
+			return fmt.Errorf("logging outputs must not contain empty entries")
+		}
+	}
+
+	if c.Logging.Rotation.MaxSizeMB < 0 || c.Logging.Rotation.MaxAge < 0 || c.Logging.Rotation.MaxBackups < 0 {
+		return fmt.Errorf("logging rotation values must be non-negative")
+	}
+
+	if c.Logging.Async.BufferSize < 0 || c.Logging.Async.PollInterval < 0 {
+		return fmt.Errorf("logging async values must be non-negative")
+	}
+
+	if c.HealthChecks.Interval < 0 || c.HealthChecks.Timeout < 0 || c.HealthChecks.DegradedLatency < 0 || c.HealthChecks.FailureThreshold < 0 {
+		return fmt.Errorf("health_checks values must be non-negative")
+	}
+	for _, check := range c.HealthChecks.Checks {
+		if check != HealthCheckDatabase && check != HealthCheckRedis {
+			return fmt.Errorf("invalid health check: %s (must be one of: database, redis)", check)
+		}
+	}
+
+	if c.Logging.ExplainSlowQueries && c.Environment == "production" {
+		return fmt.Errorf("logging explain_slow_queries can not be enabled in production")
+	}
+
+	switch c.OTEL.Exporter {
+	case TraceExporterOTLPHTTP, TraceExporterOTLPGRPC, TraceExporterStdout, TraceExporterNone:
+	default:
+		return fmt.Errorf("invalid otel exporter: %s (must be one of: otlphttp, otlpgrpc, stdout, none)", c.OTEL.Exporter)
+	}
+
+	if (c.OTEL.Exporter == TraceExporterOTLPHTTP || c.OTEL.Exporter == TraceExporterOTLPGRPC) && c.OTEL.TempoEndpoint == "" {
+		return fmt.Errorf("otel tempo_endpoint is required for the %s exporter", c.OTEL.Exporter)
+	}
+
+	if *c.OTEL.SampleRatio < 0 || *c.OTEL.SampleRatio > 1 {
+		return fmt.Errorf("otel sample_ratio must be between 0 and 1")
+	}
+
+	if c.OTEL.MaxExportBatchSize > c.OTEL.MaxQueueSize {
+		return fmt.Errorf("otel max_export_batch_size must not exceed max_queue_size")
+	}
+
+	for _, pattern := range c.Logging.Redaction.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid logging redaction pattern %q: %w", pattern, err)
+		}
+	}
+
 	return nil
 }
 
@@ -82,4 +362,3 @@ func (c *Monitor) GetLogLevel() string {
 	}
 	return c.Logging.Level
 }
-
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+const (
+	ClientIPHeaderXForwardedFor = "x-forwarded-for"
+	ClientIPHeaderXRealIP       = "x-real-ip"
+)
+
+type NetworkConfig struct {
+	// TrustedProxies are the CIDRs or IPs of proxies allowed to report the
+	// client address. Without any the peer address is the client, forwarding
+	// headers are ignored.
+	TrustedProxies []string `koanf:"trusted_proxies"`
+	// ClientIPHeader is the header trusted proxies set, x-forwarded-for or
+	// x-real-ip.
+	ClientIPHeader string `koanf:"client_ip_header"`
+
+	// AccessLists are named CIDR lists route groups opt into.
+	AccessLists map[string]AccessList `koanf:"access_lists"`
+}
+
+// AccessList denies addresses matching Deny, then, when Allow is not empty,
+// everything outside of it.
+type AccessList struct {
+	Allow []string `koanf:"allow"`
+	Deny  []string `koanf:"deny"`
+}
+
+func (c *NetworkConfig) applyDefaults() {
+	if c.ClientIPHeader == "" {
+		c.ClientIPHeader = ClientIPHeaderXForwardedFor
+	}
+	c.ClientIPHeader = strings.ToLower(c.ClientIPHeader)
+}
+
+func (c *NetworkConfig) validate() error {
+	if c.ClientIPHeader != ClientIPHeaderXForwardedFor && c.ClientIPHeader != ClientIPHeaderXRealIP {
+		return fmt.Errorf("invalid network client_ip_header: %s (must be one of: x-forwarded-for, x-real-ip)", c.ClientIPHeader)
+	}
+
+	if _, err := ParseCIDRs(c.TrustedProxies); err != nil {
+		return fmt.Errorf("network trusted_proxies: %w", err)
+	}
+
+	for name, list := range c.AccessLists {
+		if _, err := ParseCIDRs(list.Allow); err != nil {
+			return fmt.Errorf("network access list %s allow: %w", name, err)
+		}
+		if _, err := ParseCIDRs(list.Deny); err != nil {
+			return fmt.Errorf("network access list %s deny: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ParseCIDRs parses CIDRs, a bare IP is taken as a single address.
+func ParseCIDRs(values []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid ip %q", value)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cidr %q", value)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+type OIDCConfig struct {
+	Enabled      bool     `koanf:"enabled"`
+	IssuerURL    string   `koanf:"issuer_url"`
+	ClientID     string   `koanf:"client_id"`
+	ClientSecret string   `koanf:"client_secret"`
+	RedirectURL  string   `koanf:"redirect_url"`
+	Scopes       []string `koanf:"scopes"`
+
+	// Claims mapped onto user_id, user_role and the tenant of the session.
+	UserIDClaim string `koanf:"user_id_claim"`
+	RoleClaim   string `koanf:"role_claim"`
+	TenantClaim string `koanf:"tenant_claim"`
+	DefaultRole string `koanf:"default_role"`
+
+	// PostLoginRedirect is where the browser lands after a successful login.
+	PostLoginRedirect string `koanf:"post_login_redirect"`
+
+	// MockIdP serves an in-process identity provider under IssuerURL that
+	// approves every login. Refused in production.
+	MockIdP bool `koanf:"mock_idp"`
+}
+
+func (c *OIDCConfig) ApplyDefaults(port string) {
+	if c.MockIdP {
+		if c.IssuerURL == "" {
+			c.IssuerURL = "http://localhost:" + port + "/mock-idp"
+		}
+		if c.ClientID == "" {
+			c.ClientID = "mock-client"
+		}
+	}
+	if c.RedirectURL == "" {
+		c.RedirectURL = "http://localhost:" + port + "/api/v1/auth/oidc/callback"
+	}
+	if len(c.Scopes) == 0 {
+		c.Scopes = []string{"openid", "profile", "email"}
+	}
+	if c.UserIDClaim == "" {
+		c.UserIDClaim = "sub"
+	}
+	if c.RoleClaim == "" {
+		c.RoleClaim = "role"
+	}
+	if c.TenantClaim == "" {
+		c.TenantClaim = "tenant_id"
+	}
+	if c.DefaultRole == "" {
+		c.DefaultRole = "user"
+	}
+	if c.PostLoginRedirect == "" {
+		c.PostLoginRedirect = "/"
+	}
+	c.IssuerURL = strings.TrimSuffix(c.IssuerURL, "/")
+}
+
+func (c *OIDCConfig) Validate(primary Primary) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.MockIdP && primary.IsProduction() {
+		return fmt.Errorf("oidc mock_idp can not be enabled in production")
+	}
+
+	if c.IssuerURL == "" || c.ClientID == "" {
+		return fmt.Errorf("oidc issuer_url and client_id are required")
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type CORSConfig struct {
+	AllowMethods     []string `koanf:"allow_methods"`
+	AllowHeaders     []string `koanf:"allow_headers"`
+	ExposeHeaders    []string `koanf:"expose_headers"`
+	AllowCredentials bool     `koanf:"allow_credentials"`
+	MaxAge           int      `koanf:"max_age"`
+}
+
+type SecurityConfig struct {
+	HSTSMaxAge            int      `koanf:"hsts_max_age"`
+	HSTSExcludeSubdomains bool     `koanf:"hsts_exclude_subdomains"`
+	ContentSecurityPolicy string   `koanf:"content_security_policy"`
+	ContentTypeNosniff    string   `koanf:"content_type_nosniff"`
+	XFrameOptions         string   `koanf:"x_frame_options"`
+	ReferrerPolicy        string   `koanf:"referrer_policy"`
+	BodyLimit             string   `koanf:"body_limit"`
+	AllowedContentTypes   []string `koanf:"allowed_content_types"`
+}
+
+const (
+	ErrorFormatJSON    = "json"
+	ErrorFormatProblem = "problem"
+)
+
+type ErrorsConfig struct {
+	// Format is used when the client does not ask for problem+json itself.
+	Format         string `koanf:"format"`
+	ProblemBaseURI string `koanf:"problem_base_uri"`
+}
+
+type RateLimitConfig struct {
+	// Rate is the number of requests per second allowed per client.
+	Rate  float64 `koanf:"rate"`
+	Burst int     `koanf:"burst"`
+}
+
+// IsProduction reports whether the service runs in a production environment.
+func (p Primary) IsProduction() bool {
+	return strings.EqualFold(p.Env, "production")
+}
+
+// ApplyDefaults fills every unset hardening option with a safe default for
+// the given environment. Production gets HSTS and a one hour CORS preflight
+// cache, everything else stays easy to debug from a browser.
+func (c *ServerConfig) ApplyDefaults(primary Primary) {
+	production := primary.IsProduction()
+
+	if len(c.CORS.AllowMethods) == 0 {
+		c.CORS.AllowMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE"}
+	}
+	if len(c.CORS.AllowHeaders) == 0 {
+		c.CORS.AllowHeaders = []string{"Accept", "Accept-Language", "Authorization", "Content-Type", "X-Tenant-ID", "X-CSRF-Token", "X-Request-ID", "Idempotency-Key", "traceparent", "tracestate", "baggage"}
+	}
+	if len(c.CORS.ExposeHeaders) == 0 {
+		c.CORS.ExposeHeaders = []string{"X-Request-ID", "Idempotent-Replayed", "Content-Language", "traceresponse"}
+	}
+	if c.CORS.MaxAge == 0 {
+		c.CORS.MaxAge = 600
+		if production {
+			c.CORS.MaxAge = 3600
+		}
+	}
+
+	if c.Security.HSTSMaxAge == 0 && production {
+		c.Security.HSTSMaxAge = 31536000
+	}
+	if c.Security.ContentSecurityPolicy == "" {
+		c.Security.ContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+	}
+	if c.Security.ContentTypeNosniff == "" {
+		c.Security.ContentTypeNosniff = "nosniff"
+	}
+	if c.Security.XFrameOptions == "" {
+		c.Security.XFrameOptions = "DENY"
+	}
+	if c.Security.ReferrerPolicy == "" {
+		c.Security.ReferrerPolicy = "no-referrer"
+	}
+	if c.Security.BodyLimit == "" {
+		c.Security.BodyLimit = "4M"
+		if production {
+			c.Security.BodyLimit = "1M"
+		}
+	}
+	if len(c.Security.AllowedContentTypes) == 0 {
+		c.Security.AllowedContentTypes = []string{"application/json"}
+	}
+
+	if c.Errors.Format == "" {
+		c.Errors.Format = ErrorFormatJSON
+	}
+	if c.Errors.ProblemBaseURI == "" {
+		c.Errors.ProblemBaseURI = "/problems/"
+	}
+
+	if c.RateLimit.Rate == 0 {
+		c.RateLimit.Rate = 10
+	}
+
+	c.Timeouts.applyDefaults()
+	c.Network.applyDefaults()
+	c.TLS.applyDefaults()
+}
+
+func (c *ServerConfig) Validate() error {
+	if c.CORS.AllowCredentials {
+		for _, origin := range c.CORSAllowedOrigins {
+			if origin == "*" {
+				return fmt.Errorf("cors allow_credentials can not be used with wildcard origin")
+			}
+		}
+	}
+
+	if c.CORS.MaxAge < 0 {
+		return fmt.Errorf("cors max_age must be non-negative")
+	}
+
+	if c.Security.HSTSMaxAge < 0 {
+		return fmt.Errorf("security hsts_max_age must be non-negative")
+	}
+
+	if c.RateLimit.Rate < 0 || c.RateLimit.Burst < 0 {
+		return fmt.Errorf("rate_limit rate and burst must be non-negative")
+	}
+
+	if c.Errors.Format != ErrorFormatJSON && c.Errors.Format != ErrorFormatProblem {
+		return fmt.Errorf("invalid errors format: %s (must be one of: json, problem)", c.Errors.Format)
+	}
+
+	if err := c.Network.validate(); err != nil {
+		return err
+	}
+
+	if err := c.TLS.validate(); err != nil {
+		return err
+	}
+
+	return c.Timeouts.validate(time.Duration(c.WriteTimeout) * time.Second)
+}
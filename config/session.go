@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type SessionConfig struct {
+	CookieName     string `koanf:"cookie_name"`
+	CSRFCookieName string `koanf:"csrf_cookie_name"`
+	CSRFHeader     string `koanf:"csrf_header"`
+	// SameSite is one of lax or strict.
+	SameSite string `koanf:"same_site"`
+	// InsecureCookies drops the Secure flag, only for plain HTTP development
+	// hosts other than localhost.
+	InsecureCookies bool `koanf:"insecure_cookies"`
+	// IdleTimeout is extended on every request, AbsoluteTimeout is the hard
+	// limit counted from login.
+	IdleTimeout     time.Duration `koanf:"idle_timeout"`
+	AbsoluteTimeout time.Duration `koanf:"absolute_timeout"`
+	// AdminRole is the session role allowed on /admin routes.
+	AdminRole string `koanf:"admin_role"`
+	// StaffRoles may search students and read their contact details.
+	StaffRoles []string `koanf:"staff_roles"`
+}
+
+func (c *SessionConfig) ApplyDefaults(primary Primary) {
+	if c.CookieName == "" {
+		c.CookieName = "session"
+	}
+	if c.CSRFCookieName == "" {
+		c.CSRFCookieName = "csrf_token"
+	}
+	if c.CSRFHeader == "" {
+		c.CSRFHeader = "X-CSRF-Token"
+	}
+	if c.SameSite == "" {
+		c.SameSite = "lax"
+	}
+	if c.IdleTimeout == 0 {
+		c.IdleTimeout = 2 * time.Hour
+		if primary.IsProduction() {
+			c.IdleTimeout = 30 * time.Minute
+		}
+	}
+	if c.AbsoluteTimeout == 0 {
+		c.AbsoluteTimeout = 24 * time.Hour
+	}
+	if c.AdminRole == "" {
+		c.AdminRole = "admin"
+	}
+	if len(c.StaffRoles) == 0 {
+		c.StaffRoles = []string{c.AdminRole, "staff"}
+	}
+}
+
+func (c *SessionConfig) Validate() error {
+	if c.SameSite != "lax" && c.SameSite != "strict" {
+		return fmt.Errorf("invalid session same_site: %s (must be one of: lax, strict)", c.SameSite)
+	}
+
+	if c.IdleTimeout < 0 || c.AbsoluteTimeout < 0 {
+		return fmt.Errorf("session idle_timeout and absolute_timeout must be non-negative")
+	}
+
+	if c.IdleTimeout > c.AbsoluteTimeout {
+		return fmt.Errorf("session idle_timeout must not exceed absolute_timeout")
+	}
+
+	return nil
+}
+
+func (c *SessionConfig) SameSiteMode() http.SameSite {
+	if c.SameSite == "strict" {
+		return http.SameSiteStrictMode
+	}
+	return http.SameSiteLaxMode
+}
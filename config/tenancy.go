@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+type TenancyConfig struct {
+	Enabled bool `koanf:"enabled"`
+	// Required rejects requests whose tenant can not be resolved.
+	Required bool `koanf:"required"`
+	// Claim binds the caller to a tenant, like the tenant of a session. Header
+	// and the subdomain of BaseDomain may only name that same tenant.
+	Header     string `koanf:"header"`
+	Claim      string `koanf:"claim"`
+	BaseDomain string `koanf:"base_domain"`
+
+	Overrides map[string]TenantOverride `koanf:"overrides"`
+}
+
+// TenantOverride replaces server wide settings for a single tenant, zero
+// values keep the server setting.
+type TenantOverride struct {
+	RateLimit float64 `koanf:"rate_limit"`
+	Burst     int     `koanf:"burst"`
+}
+
+func (c *TenancyConfig) ApplyDefaults() {
+	if c.Header == "" {
+		c.Header = "X-Tenant-ID"
+	}
+	if c.Claim == "" {
+		c.Claim = "tenant_id"
+	}
+	c.BaseDomain = strings.TrimPrefix(strings.ToLower(c.BaseDomain), ".")
+}
+
+func (c *TenancyConfig) Validate() error {
+	for id, override := range c.Overrides {
+		if override.RateLimit < 0 || override.Burst < 0 {
+			return fmt.Errorf("tenancy override %s: rate_limit and burst must be non-negative", id)
+		}
+	}
+
+	return nil
+}
+
+// RateLimitFor returns the rate limit and burst that apply to the tenant.
+func (c *Config) RateLimitFor(tenantID string) (float64, int) {
+	limit, burst := c.Server.RateLimit.Rate, c.Server.RateLimit.Burst
+
+	if override, ok := c.Tenancy.Overrides[tenantID]; ok {
+		if override.RateLimit > 0 {
+			limit = override.RateLimit
+		}
+		if override.Burst > 0 {
+			burst = override.Burst
+		}
+	}
+
+	return limit, burst
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeoutPolicy names a request deadline shared by a group of routes.
+type TimeoutPolicy string
+
+const (
+	TimeoutPolicyDefault TimeoutPolicy = "default"
+	TimeoutPolicyRead    TimeoutPolicy = "read"
+	TimeoutPolicyWrite   TimeoutPolicy = "write"
+)
+
+type TimeoutConfig struct {
+	Default time.Duration `koanf:"default"`
+	Read    time.Duration `koanf:"read"`
+	Write   time.Duration `koanf:"write"`
+}
+
+// Policy returns the deadline configured for the policy, falling back to the
+// default one for unknown names.
+func (c TimeoutConfig) Policy(policy TimeoutPolicy) time.Duration {
+	switch policy {
+	case TimeoutPolicyRead:
+		return c.Read
+	case TimeoutPolicyWrite:
+		return c.Write
+	default:
+		return c.Default
+	}
+}
+
+func (c *TimeoutConfig) applyDefaults() {
+	if c.Default == 0 {
+		c.Default = 10 * time.Second
+	}
+	if c.Read == 0 {
+		c.Read = 5 * time.Second
+	}
+	if c.Write == 0 {
+		c.Write = 10 * time.Second
+	}
+}
+
+func (c TimeoutConfig) validate(writeTimeout time.Duration) error {
+	for policy, timeout := range map[TimeoutPolicy]time.Duration{
+		TimeoutPolicyDefault: c.Default,
+		TimeoutPolicyRead:    c.Read,
+		TimeoutPolicyWrite:   c.Write,
+	} {
+		if timeout < 0 {
+			return fmt.Errorf("timeouts %s must be non-negative", policy)
+		}
+		// A deadline past the server write timeout can never be reported.
+		if writeTimeout > 0 && timeout >= writeTimeout {
+			return fmt.Errorf("timeouts %s (%s) must be shorter than server write_timeout (%s)", policy, timeout, writeTimeout)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+type TLSConfig struct {
+	Enabled  bool   `koanf:"enabled"`
+	CertFile string `koanf:"cert_file"`
+	KeyFile  string `koanf:"key_file"`
+	// MinVersion is 1.2 or 1.3.
+	MinVersion string `koanf:"min_version"`
+	// CipherSuites are Go cipher suite names, they only apply to TLS 1.2.
+	// Empty uses the Go defaults.
+	CipherSuites []string `koanf:"cipher_suites"`
+
+	// ClientAuth is none, request (verify a certificate when one is sent) or
+	// require. Client certificates are verified against ClientCAFile.
+	ClientAuth   string `koanf:"client_auth"`
+	ClientCAFile string `koanf:"client_ca_file"`
+
+	// ReloadInterval is how often the files are checked for changes, they are
+	// also reloaded on SIGHUP. Zero only reloads on SIGHUP.
+	ReloadInterval time.Duration `koanf:"reload_interval"`
+}
+
+func (c *TLSConfig) applyDefaults() {
+	if c.MinVersion == "" {
+		c.MinVersion = "1.2"
+	}
+	if c.ClientAuth == "" {
+		c.ClientAuth = ClientAuthNone
+	}
+	c.ClientAuth = strings.ToLower(c.ClientAuth)
+	if c.ReloadInterval == 0 {
+		c.ReloadInterval = time.Minute
+	}
+}
+
+func (c *TLSConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.CertFile == "" || c.KeyFile == "" {
+		return fmt.Errorf("tls cert_file and key_file are required")
+	}
+
+	if _, err := c.TLSVersion(); err != nil {
+		return err
+	}
+
+	if _, err := c.CipherSuiteIDs(); err != nil {
+		return err
+	}
+
+	switch c.ClientAuth {
+	case ClientAuthNone:
+	case ClientAuthRequest, ClientAuthRequire:
+		if c.ClientCAFile == "" {
+			return fmt.Errorf("tls client_ca_file is required when client_auth is %s", c.ClientAuth)
+		}
+	default:
+		return fmt.Errorf("invalid tls client_auth: %s (must be one of: none, request, require)", c.ClientAuth)
+	}
+
+	if c.ReloadInterval < 0 {
+		return fmt.Errorf("tls reload_interval must be non-negative")
+	}
+
+	return nil
+}
+
+func (c *TLSConfig) TLSVersion() (uint16, error) {
+	switch c.MinVersion {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("invalid tls min_version: %s (must be one of: 1.2, 1.3)", c.MinVersion)
+	}
+}
+
+// CipherSuiteIDs resolves CipherSuites, suites Go considers insecure are
+// refused.
+func (c *TLSConfig) CipherSuiteIDs() ([]uint16, error) {
+	if len(c.CipherSuites) == 0 {
+		return nil, nil
+	}
+
+	known := map[string]uint16{}
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, 0, len(c.CipherSuites))
+	for _, name := range c.CipherSuites {
+		id, ok := known[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown or insecure tls cipher suite: %s", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (c *TLSConfig) ClientAuthType() tls.ClientAuthType {
+	switch c.ClientAuth {
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type WebhookConfig struct {
+	// Tolerance is how far the signed timestamp may drift from server time,
+	// nonces are remembered for twice as long.
+	Tolerance time.Duration `koanf:"tolerance"`
+
+	Sources map[string]WebhookSource `koanf:"sources"`
+}
+
+// WebhookSource is a sender such as the payment processor. The signature
+// header holds hex(HMAC-SHA256(secret, timestamp + "." + body)), optionally
+// prefixed with "sha256=". Several comma separated signatures are accepted so
+// senders can rotate secrets.
+type WebhookSource struct {
+	// Secrets are tried in order, more than one only while rotating.
+	Secrets         []string `koanf:"secrets"`
+	SignatureHeader string   `koanf:"signature_header"`
+	TimestampHeader string   `koanf:"timestamp_header"`
+	// DeliveryHeader carries the sender's unique delivery id, stored with the
+	// event. It is not signed, the replay nonce is a hash of the signed
+	// timestamp and body. Without it the signature is the delivery id.
+	DeliveryHeader  string `koanf:"delivery_header"`
+	EventTypeHeader string `koanf:"event_type_header"`
+}
+
+func (c *WebhookConfig) ApplyDefaults() {
+	if c.Tolerance == 0 {
+		c.Tolerance = 5 * time.Minute
+	}
+
+	for name, source := range c.Sources {
+		if source.SignatureHeader == "" {
+			source.SignatureHeader = "X-Webhook-Signature"
+		}
+		if source.TimestampHeader == "" {
+			source.TimestampHeader = "X-Webhook-Timestamp"
+		}
+		if source.DeliveryHeader == "" {
+			source.DeliveryHeader = "X-Webhook-ID"
+		}
+		if source.EventTypeHeader == "" {
+			source.EventTypeHeader = "X-Webhook-Event"
+		}
+		c.Sources[name] = source
+	}
+}
+
+func (c *WebhookConfig) Validate() error {
+	if c.Tolerance < 0 {
+		return fmt.Errorf("webhook tolerance must be non-negative")
+	}
+
+	for name, source := range c.Sources {
+		if len(source.Secrets) == 0 {
+			return fmt.Errorf("webhook source %s: at least one secret is required", name)
+		}
+		for _, secret := range source.Secrets {
+			if len(secret) < 16 {
+				return fmt.Errorf("webhook source %s: secrets must be at least 16 characters", name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+// Package health runs the configured dependency checks in the background so
+// probes are answered from the last results instead of pinging on every hit.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+const (
+	StatusHealthy   = "healthy"
+	StatusDegraded  = "degraded"
+	StatusUnhealthy = "unhealthy"
+	// StatusStarting is reported until the first round of checks finished.
+	StatusStarting = "starting"
+)
+
+// CheckFunc reports whether a dependency works, it must honour ctx.
+type CheckFunc func(ctx context.Context) error
+
+type Checker struct {
+	cfg    config.HealthChecksConfig
+	env    string
+	logger *zerolog.Logger
+	checks map[string]CheckFunc
+
+	mu       sync.RWMutex
+	results  map[string]*model.Check
+	checked  bool
+	failures map[string]int
+}
+
+// New keeps the checks named in cfg, funcs holds every available check.
+func New(cfg config.HealthChecksConfig, env string, logger *zerolog.Logger, funcs map[string]CheckFunc) *Checker {
+	checks := make(map[string]CheckFunc, len(cfg.Checks))
+	for _, name := range cfg.Checks {
+		if fn, ok := funcs[name]; ok {
+			checks[name] = fn
+		}
+	}
+
+	return &Checker{
+		cfg:      cfg,
+		env:      env,
+		logger:   logger,
+		checks:   checks,
+		results:  map[string]*model.Check{},
+		failures: map[string]int{},
+	}
+}
+
+// Run checks right away and then every Interval until ctx is done. With
+// checks disabled it returns and the service always reports ready.
+func (h *Checker) Run(ctx context.Context) {
+	if !h.cfg.Enabled {
+		return
+	}
+
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		h.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *Checker) checkAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for name, fn := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h.check(ctx, name, fn)
+		}()
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	h.checked = true
+	h.mu.Unlock()
+}
+
+func (h *Checker) check(ctx context.Context, name string, fn CheckFunc) {
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	elapsed := time.Since(start)
+	if ctx.Err() != nil && err == nil {
+		err = ctx.Err()
+	}
+
+	result := &model.Check{
+		Name:         name,
+		Status:       StatusHealthy,
+		ResponseTime: elapsed.String(),
+		CheckedAt:    time.Now().UTC(),
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		h.failures[name]++
+		result.Error = err.Error()
+		result.ConsecutiveFailures = h.failures[name]
+		result.Status = StatusDegraded
+		if h.failures[name] >= h.cfg.FailureThreshold {
+			result.Status = StatusUnhealthy
+		}
+	} else {
+		h.failures[name] = 0
+		if h.cfg.DegradedLatency > 0 && elapsed > h.cfg.DegradedLatency {
+			result.Status = StatusDegraded
+		}
+	}
+
+	// Only transitions are logged, a steady state would flood the logs.
+	previous := StatusStarting
+	if last, ok := h.results[name]; ok {
+		previous = last.Status
+	}
+	if previous != result.Status {
+		event := h.logger.Info()
+		switch result.Status {
+		case StatusUnhealthy:
+			event = h.logger.Error()
+		case StatusDegraded:
+			event = h.logger.Warn()
+		}
+		event.
+			Str("check", name).
+			Str("from", previous).
+			Str("to", result.Status).
+			Dur("response_time", elapsed).
+			AnErr("check_error", err).
+			Msg("health check changed state")
+	}
+
+	h.results[name] = result
+}
+
+// Report is the last result of every check. The overall status is the worst
+// of them.
+func (h *Checker) Report() *model.Report {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	report := &model.Report{
+		Status:      StatusHealthy,
+		Timestamp:   time.Now().UTC(),
+		Environment: h.env,
+		Checks:      []model.Check{},
+	}
+	if h.cfg.Enabled && !h.checked {
+		report.Status = StatusStarting
+	}
+
+	for _, name := range h.cfg.Checks {
+		result, ok := h.results[name]
+		if !ok {
+			continue
+		}
+		report.Checks = append(report.Checks, *result)
+
+		switch {
+		case result.Status == StatusUnhealthy:
+			report.Status = StatusUnhealthy
+		case result.Status == StatusDegraded && report.Status == StatusHealthy:
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// Ready reports whether the service should receive traffic, degraded checks
+// keep it ready.
+func Ready(report *model.Report) bool {
+	return report.Status == StatusHealthy || report.Status == StatusDegraded
+}
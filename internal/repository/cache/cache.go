@@ -2,18 +2,43 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/pkg/metrics"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrNotFound is returned by Get when the key does not exist.
+var ErrNotFound = errors.New("cache: key not found")
+
+// deleteIfEqual removes a key only while it still holds the expected value.
+var deleteIfEqual = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 type Provider interface {
 	Close() error
 	Ping(ctx context.Context) error
+	PoolStats() *redis.PoolStats
+
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, keys ...string) error
+	DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error)
+	ExtendExpire(ctx context.Context, key string, ttl time.Duration) error
+
+	SAdd(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	SRem(ctx context.Context, key string, members ...string) error
 }
 
 type cache struct {
@@ -21,14 +46,20 @@ type cache struct {
 	Client *redis.Client
 }
 
-func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer) (Provider, error) {
-	if config == nil || logger == nil {
-		return nil, fmt.Errorf("config and logger must not be nil")
+func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer, metrics *metrics.Metrics) (Provider, error) {
+	if config == nil || logger == nil || metrics == nil {
+		return nil, fmt.Errorf("config, logger and metrics must not be nil")
 	}
 
 	opt, _ := redis.ParseURL(config.Redis.Address)
 
 	redisClient := redis.NewClient(opt)
+	redisClient.AddHook(&instrumentation{
+		logger:  logger,
+		tracer:  tracer,
+		metrics: metrics,
+		slow:    config.Redis.SlowCommandThreshold,
+	})
 
 	// Validate connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -51,6 +82,74 @@ func (c *cache) Ping(ctx context.Context) error {
 	return c.Client.Ping(ctx).Err()
 }
 
+func (c *cache) PoolStats() *redis.PoolStats {
+	return c.Client.PoolStats()
+}
+
+func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.Client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (c *cache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.Client.Set(ctx, key, value, ttl).Err()
+}
+
+// SetNX stores the value only if the key does not exist yet and reports
+// whether it was stored.
+func (c *cache) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	return c.Client.SetNX(ctx, key, value, ttl).Result()
+}
+
+func (c *cache) Delete(ctx context.Context, keys ...string) error {
+	return c.Client.Del(ctx, keys...).Err()
+}
+
+// DeleteIfEqual deletes the key only if it still holds value and reports
+// whether it did, so a lock is never released by anyone but its owner.
+func (c *cache) DeleteIfEqual(ctx context.Context, key string, value []byte) (bool, error) {
+	deleted, err := deleteIfEqual.Run(ctx, c.Client, []string{key}, value).Int()
+	if err != nil {
+		return false, err
+	}
+	return deleted == 1, nil
+}
+
+// ExtendExpire sets the ttl of a key without one and otherwise only ever
+// moves its expiry later. NX covers keys without a ttl, which GT treats as
+// never expiring.
+func (c *cache) ExtendExpire(ctx context.Context, key string, ttl time.Duration) error {
+	_, err := c.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ExpireNX(ctx, key, ttl)
+		pipe.ExpireGT(ctx, key, ttl)
+		return nil
+	})
+	return err
+}
+
+func (c *cache) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.Client.SAdd(ctx, key, toAny(members)...).Err()
+}
+
+func (c *cache) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.Client.SMembers(ctx, key).Result()
+}
+
+func (c *cache) SRem(ctx context.Context, key string, members ...string) error {
+	return c.Client.SRem(ctx, key, toAny(members)...).Err()
+}
+
+func toAny(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
 // Close gracefully closes the Redis connection
 func (c *cache) Close() error {
 	if err := c.Client.Close(); err != nil {
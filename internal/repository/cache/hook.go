@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// readCommands are the lookups counted as cache hits or misses.
+var readCommands = map[string]bool{
+	"get":    true,
+	"getex":  true,
+	"getdel": true,
+	"hget":   true,
+}
+
+// instrumentation traces and measures every command. Arguments are never
+// recorded, keys and values may hold session tokens or personal data.
+type instrumentation struct {
+	logger  *zerolog.Logger
+	tracer  trace.Tracer
+	metrics *metrics.Metrics
+	slow    time.Duration
+}
+
+func (h *instrumentation) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+func (h *instrumentation) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		name := cmd.FullName()
+
+		ctx, span := h.tracer.Start(ctx, "redis."+name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", name),
+			),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := next(ctx, cmd)
+		elapsed := time.Since(start)
+
+		failed := h.finish(span, cmd, err)
+		h.metrics.ObserveRedisCommand(name, failed, elapsed)
+
+		if elapsed > h.slow {
+			logger.FromContext(ctx, h.logger).Warn().
+				Str("command", name).
+				Dur("duration", elapsed).
+				Msg("slow redis command")
+		}
+
+		return err
+	}
+}
+
+func (h *instrumentation) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.FullName()
+		}
+
+		ctx, span := h.tracer.Start(ctx, "redis.pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system", "redis"),
+				attribute.String("db.operation", "pipeline"),
+				attribute.StringSlice("db.redis.commands", names),
+			),
+		)
+		defer span.End()
+
+		start := time.Now()
+		err := next(ctx, cmds)
+		elapsed := time.Since(start)
+
+		failed := false
+		for _, cmd := range cmds {
+			if h.finish(span, cmd, cmd.Err()) {
+				failed = true
+			}
+		}
+		h.metrics.ObserveRedisCommand("pipeline", failed, elapsed)
+
+		if elapsed > h.slow {
+			logger.FromContext(ctx, h.logger).Warn().
+				Str("command", "pipeline").
+				Str("commands", strings.Join(names, ",")).
+				Dur("duration", elapsed).
+				Msg("slow redis command")
+		}
+
+		return err
+	}
+}
+
+// finish counts cache reads and records a failure on span. A missing key is
+// a miss, not an error.
+func (h *instrumentation) finish(span trace.Span, cmd redis.Cmder, err error) bool {
+	if readCommands[cmd.Name()] && (err == nil || errors.Is(err, redis.Nil)) {
+		h.metrics.CacheRead(err == nil)
+	}
+
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return true
+}
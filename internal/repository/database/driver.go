@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // Driver is an interface for database.
@@ -11,7 +13,9 @@ type Driver interface {
 	Ping(ctx context.Context) error
 	IsInitialized(ctx context.Context) bool
 	Close() error
+	PoolStats() *pgxpool.Stat
 
 	// Other methods related to database operation
 	Student
+	Webhook
 }
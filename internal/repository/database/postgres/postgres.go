@@ -14,13 +14,17 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/shanto-323/backend-scaffold/config"
 	"github.com/shanto-323/backend-scaffold/internal/repository/database"
+	"github.com/shanto-323/backend-scaffold/internal/tenant"
+	"github.com/shanto-323/backend-scaffold/pkg/keyring"
 	loggerConfig "github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/redact"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type DB struct {
-	pool   *pgxpool.Pool
-	logger *zerolog.Logger
+	pool    *pgxpool.Pool
+	logger  *zerolog.Logger
+	keyring *keyring.Keyring
 }
 
 type multiTracer struct {
@@ -66,41 +70,62 @@ func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer) (da
 		return nil, fmt.Errorf("failed to parse pgx pool config: %w", err)
 	}
 
+	tracers := []any{}
 	if tracer != nil {
-		pgxPoolConfig.ConnConfig.Tracer = otelpgx.NewTracer()
+		tracers = append(tracers, otelpgx.NewTracer())
 	}
 
+	// Slow queries are logged in every environment.
+	slowQueries := &slowQueryTracer{
+		logger:    logger,
+		threshold: config.Monitor.Logging.SlowQueryThreshold,
+		explain:   config.Monitor.Logging.ExplainSlowQueries,
+	}
+	tracers = append(tracers, slowQueries)
+
 	if config.Primary.Env == "local" {
-		globalLevel := logger.GetLevel()
-		pgxLogger := loggerConfig.NewPgxLogger(globalLevel)
-
-		if pgxPoolConfig.ConnConfig.Tracer != nil {
-			// If New Relic tracer exists, create a multi-tracer
-			localTracer := &tracelog.TraceLog{
-				Logger:   pgxzero.NewLogger(pgxLogger),
-				LogLevel: tracelog.LogLevel(loggerConfig.GetPgxTraceLogLevel(globalLevel)),
-			}
-			pgxPoolConfig.ConnConfig.Tracer = &multiTracer{
-				tracers: []any{pgxPoolConfig.ConnConfig.Tracer, localTracer},
-			}
-		} else {
-			pgxPoolConfig.ConnConfig.Tracer = &tracelog.TraceLog{
-				Logger:   pgxzero.NewLogger(pgxLogger),
-				LogLevel: tracelog.LogLevel(loggerConfig.GetPgxTraceLogLevel(globalLevel)),
-			}
+		redactor, err := redact.New(config.Monitor.Logging.Redaction)
+		if err != nil {
+			return nil, err
+		}
+		pgxLogger := loggerConfig.NewPgxLogger(redactor)
+
+		// Everything is handed to the logger, the database component level
+		// filters at runtime.
+		tracers = append(tracers, &tracelog.TraceLog{
+			Logger:   pgxzero.NewLogger(pgxLogger),
+			LogLevel: tracelog.LogLevelTrace,
+		})
+	}
+
+	pgxPoolConfig.ConnConfig.Tracer = &multiTracer{tracers: tracers}
+
+	// Row level security policies read the tenant from app.tenant_id, set it on
+	// every acquire so a pooled connection never keeps a previous tenant.
+	pgxPoolConfig.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		if _, err := conn.Exec(ctx, "SELECT set_config('app.tenant_id', $1, false)", tenant.FromContext(ctx)); err != nil {
+			return false, fmt.Errorf("failed to set tenant: %w", err)
 		}
+		return true, nil
+	}
+
+	keys, err := keyring.New(config.Encryption, config.Primary.SecretKey)
+	if err != nil {
+		return nil, err
 	}
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), pgxPoolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pgx pool: %w", err)
 	}
+	slowQueries.pool = pool
 
 	logger.Info().Msg("postgres service initialized successfully")
 
 	return &DB{
-		pool:   pool,
-		logger: logger,
+		pool:    pool,
+		logger:  logger,
+		keyring: keys,
 	}, nil
 }
 
@@ -113,6 +138,10 @@ func (db *DB) IsInitialized(ctx context.Context) bool {
 	return db.pool != nil
 }
 
+func (db *DB) PoolStats() *pgxpool.Stat {
+	return db.pool.Stat()
+}
+
 func (db *DB) Close() error {
 	db.logger.Info().Msg("closing database connection pool")
 	db.pool.Close()
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
+)
+
+// explainTimeout bounds the EXPLAIN run after a slow query, it executes the
+// query a second time.
+const explainTimeout = 10 * time.Second
+
+type slowQueryStartKey struct{}
+
+// explainingKey marks the EXPLAIN of a slow query so it is not explained in
+// turn.
+type explainingKey struct{}
+
+type slowQueryStart struct {
+	sql   string
+	args  []any
+	start time.Time
+}
+
+// slowQueryTracer logs queries slower than threshold with their fingerprint,
+// never with their arguments. The request logger in ctx adds request_id and
+// the trace ids. With explain set, read-only slow queries are run again under
+// EXPLAIN (ANALYZE, BUFFERS) on another connection and the plan is logged.
+type slowQueryTracer struct {
+	logger    *zerolog.Logger
+	threshold time.Duration
+	explain   bool
+	// pool is set once the pool exists, EXPLAIN needs its own connection.
+	pool *pgxpool.Pool
+}
+
+func (t *slowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryStartKey{}, &slowQueryStart{
+		sql:   data.SQL,
+		args:  data.Args,
+		start: time.Now(),
+	})
+}
+
+func (t *slowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(slowQueryStartKey{}).(*slowQueryStart)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(query.start)
+	if elapsed < t.threshold || ctx.Value(explainingKey{}) != nil {
+		return
+	}
+
+	fingerprint := normalizeQuery(query.sql)
+	log := logger.FromContext(ctx, t.logger).With().
+		Str("query_id", fingerprintID(fingerprint)).
+		Str("query", fingerprint).
+		Dur("duration", elapsed).
+		Int64("rows", data.CommandTag.RowsAffected()).
+		Logger()
+
+	event := log.Warn()
+	if data.Err != nil {
+		event = event.Err(data.Err)
+	}
+	event.Msg("slow query")
+
+	if t.explain && t.pool != nil && readOnly(fingerprint) {
+		go t.explainQuery(context.WithoutCancel(ctx), query, log)
+	}
+}
+
+func (t *slowQueryTracer) explainQuery(ctx context.Context, query *slowQueryStart, log zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, explainingKey{}, true), explainTimeout)
+	defer cancel()
+
+	rows, err := t.pool.Query(ctx, "EXPLAIN (ANALYZE, BUFFERS) "+query.sql, query.args...)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to explain slow query")
+		return
+	}
+
+	plan, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		log.Error().Err(err).Msg("failed to explain slow query")
+		return
+	}
+
+	log.Info().Str("plan", strings.Join(plan, "\n")).Msg("slow query plan")
+}
+
+var (
+	fingerprintComments = regexp.MustCompile(`(?s)--[^\n]*|/\*.*?\*/`)
+	fingerprintStrings  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	fingerprintParams   = regexp.MustCompile(`\$\d+`)
+	fingerprintNumbers  = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	fingerprintLists    = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)+\s*\)`)
+	fingerprintSpaces   = regexp.MustCompile(`\s+`)
+)
+
+// normalizeQuery fingerprints a statement, queries differing only in
+// literals, parameters, IN list lengths or layout share one fingerprint.
+func normalizeQuery(sql string) string {
+	sql = fingerprintComments.ReplaceAllString(sql, " ")
+	sql = fingerprintStrings.ReplaceAllString(sql, "?")
+	sql = fingerprintParams.ReplaceAllString(sql, "?")
+	sql = fingerprintNumbers.ReplaceAllString(sql, "?")
+	sql = fingerprintLists.ReplaceAllString(sql, "(...)")
+	sql = fingerprintSpaces.ReplaceAllString(sql, " ")
+	return strings.ToLower(strings.TrimSpace(sql))
+}
+
+// fingerprintID is a short stable id for grouping slow query log lines.
+func fingerprintID(fingerprint string) string {
+	sum := sha256.Sum256([]byte(fingerprint))
+	return hex.EncodeToString(sum[:8])
+}
+
+// readOnly reports whether running the statement again is safe. EXPLAIN
+// ANALYZE executes it, writes would be applied twice.
+func readOnly(fingerprint string) bool {
+	if !strings.HasPrefix(fingerprint, "select ") {
+		return false
+	}
+	for _, keyword := range []string{" for update", " for share", " for no key update", " for key share", "nextval(", "set_config("} {
+		if strings.Contains(fingerprint, keyword) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,7 +1,229 @@
 package postgres
 
-import "fmt"
+import (
+	"context"
+	"fmt"
 
-func (d *DB) CreateStudent() {
-	fmt.Println("This is Create Student Function")
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/shanto-323/backend-scaffold/model"
+	"github.com/shanto-323/backend-scaffold/pkg/keyring"
+)
+
+const studentColumns = `id, name, roll, key_id, phone_enc, address_enc, guardian_contact_enc`
+
+// Names of the encrypted student columns, also used as additional data so a
+// value only decrypts in the row and column it was written to.
+const (
+	studentPhone           = "phone"
+	studentAddress         = "address"
+	studentGuardianContact = "guardian_contact"
+)
+
+// encryptedStudent is a students row as stored, with contact details sealed.
+type encryptedStudent struct {
+	keyID                *string
+	phone                []byte
+	address              []byte
+	guardianContact      []byte
+	phoneIndex           *string
+	guardianContactIndex *string
+}
+
+func (d *DB) CreateStudent(ctx context.Context, student *model.Student) error {
+	row, err := d.encryptStudent(student)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.pool.Exec(ctx,
+		`INSERT INTO students (id, name, roll, key_id, phone_enc, address_enc, guardian_contact_enc, phone_bidx, guardian_contact_bidx)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		student.ID, student.Name, student.Roll,
+		row.keyID, row.phone, row.address, row.guardianContact, row.phoneIndex, row.guardianContactIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert student: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) SearchStudents(ctx context.Context, filter *model.SearchStudentsRequest) ([]*model.Student, error) {
+	var phoneIndex, guardianContactIndex *string
+	if filter.Phone != "" {
+		index := d.keyring.BlindIndex(studentPhone, filter.Phone)
+		phoneIndex = &index
+	}
+	if filter.GuardianContact != "" {
+		index := d.keyring.BlindIndex(studentGuardianContact, filter.GuardianContact)
+		guardianContactIndex = &index
+	}
+
+	rows, err := d.pool.Query(ctx,
+		`SELECT `+studentColumns+` FROM students
+		WHERE ($1::text IS NULL OR phone_bidx = $1)
+		AND ($2::text IS NULL OR guardian_contact_bidx = $2)
+		ORDER BY roll`,
+		phoneIndex, guardianContactIndex,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search students: %w", err)
+	}
+
+	students, err := pgx.CollectRows(rows, d.scanStudent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search students: %w", err)
+	}
+
+	// Blind indexes can collide, the decrypted values have the final word.
+	matches := students[:0]
+	for _, student := range students {
+		if filter.Phone != "" && !sameIndexedValue(student.Phone, filter.Phone) {
+			continue
+		}
+		if filter.GuardianContact != "" && !sameIndexedValue(student.GuardianContact, filter.GuardianContact) {
+			continue
+		}
+		matches = append(matches, student)
+	}
+	return matches, nil
+}
+
+// ReencryptStudents runs with the maintenance policy, the job is not bound to
+// a tenant. Rows are locked and skipped if busy so several instances can run
+// the job at once.
+func (d *DB) ReencryptStudents(ctx context.Context, limit int) (int, error) {
+	tx, err := d.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin re-encryption: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT set_config('app.maintenance', 'on', true)`); err != nil {
+		return 0, fmt.Errorf("failed to enable maintenance policy: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+studentColumns+` FROM students
+		WHERE key_id IS NOT NULL AND key_id <> $1
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED`,
+		d.keyring.ActiveKeyID(), limit,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select students to re-encrypt: %w", err)
+	}
+
+	students, err := pgx.CollectRows(rows, d.scanStudent)
+	if err != nil {
+		return 0, fmt.Errorf("failed to select students to re-encrypt: %w", err)
+	}
+
+	for _, student := range students {
+		row, err := d.encryptStudent(student)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := tx.Exec(ctx,
+			`UPDATE students SET key_id = $2, phone_enc = $3, address_enc = $4, guardian_contact_enc = $5,
+			phone_bidx = $6, guardian_contact_bidx = $7 WHERE id = $1`,
+			student.ID, row.keyID, row.phone, row.address, row.guardianContact, row.phoneIndex, row.guardianContactIndex,
+		); err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt student %s: %w", student.ID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit re-encryption: %w", err)
+	}
+	return len(students), nil
+}
+
+func (d *DB) scanStudent(row pgx.CollectableRow) (*model.Student, error) {
+	student := &model.Student{}
+	var keyID *string
+	var phone, address, guardianContact []byte
+
+	if err := row.Scan(&student.ID, &student.Name, &student.Roll, &keyID, &phone, &address, &guardianContact); err != nil {
+		return nil, err
+	}
+	if keyID == nil {
+		return student, nil
+	}
+
+	var err error
+	if student.Phone, err = d.decryptField(*keyID, student.ID, studentPhone, phone); err != nil {
+		return nil, err
+	}
+	if student.Address, err = d.decryptField(*keyID, student.ID, studentAddress, address); err != nil {
+		return nil, err
+	}
+	if student.GuardianContact, err = d.decryptField(*keyID, student.ID, studentGuardianContact, guardianContact); err != nil {
+		return nil, err
+	}
+	return student, nil
+}
+
+func (d *DB) encryptStudent(student *model.Student) (*encryptedStudent, error) {
+	row := &encryptedStudent{}
+	if student.Phone == "" && student.Address == "" && student.GuardianContact == "" {
+		return row, nil
+	}
+
+	keyID := d.keyring.ActiveKeyID()
+	row.keyID = &keyID
+
+	var err error
+	if row.phone, err = d.encryptField(student.ID, studentPhone, student.Phone); err != nil {
+		return nil, err
+	}
+	if row.address, err = d.encryptField(student.ID, studentAddress, student.Address); err != nil {
+		return nil, err
+	}
+	if row.guardianContact, err = d.encryptField(student.ID, studentGuardianContact, student.GuardianContact); err != nil {
+		return nil, err
+	}
+
+	if student.Phone != "" {
+		index := d.keyring.BlindIndex(studentPhone, student.Phone)
+		row.phoneIndex = &index
+	}
+	if student.GuardianContact != "" {
+		index := d.keyring.BlindIndex(studentGuardianContact, student.GuardianContact)
+		row.guardianContactIndex = &index
+	}
+	return row, nil
+}
+
+func (d *DB) encryptField(id uuid.UUID, column, value string) ([]byte, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	ciphertext, err := d.keyring.Encrypt([]byte(value), studentAdditionalData(id, column))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt student %s: %w", column, err)
+	}
+	return ciphertext, nil
+}
+
+func (d *DB) decryptField(keyID string, id uuid.UUID, column string, ciphertext []byte) (string, error) {
+	if ciphertext == nil {
+		return "", nil
+	}
+
+	plaintext, err := d.keyring.Decrypt(keyID, ciphertext, studentAdditionalData(id, column))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt student %s %s: %w", id, column, err)
+	}
+	return string(plaintext), nil
+}
+
+func studentAdditionalData(id uuid.UUID, column string) []byte {
+	return []byte("students:" + id.String() + ":" + column)
+}
+
+func sameIndexedValue(stored, wanted string) bool {
+	return keyring.Normalize(stored) == keyring.Normalize(wanted)
 }
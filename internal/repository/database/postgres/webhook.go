@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+func (d *DB) CreateWebhookEvent(ctx context.Context, event *model.WebhookEvent) error {
+	err := d.pool.QueryRow(ctx,
+		`INSERT INTO webhook_events (id, source, event_type, delivery_id, content_type, payload, status, trace_context)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING received_at`,
+		event.ID, event.Source, event.EventType, event.DeliveryID, event.ContentType, event.Payload, event.Status, event.TraceContext,
+	).Scan(&event.ReceivedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert webhook event: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) GetWebhookEvent(ctx context.Context, id uuid.UUID) (*model.WebhookEvent, error) {
+	event := &model.WebhookEvent{}
+	var eventErr *string
+
+	err := d.pool.QueryRow(ctx,
+		`SELECT id, source, event_type, delivery_id, content_type, payload, trace_context, status, error, attempts, received_at, processed_at
+		FROM webhook_events WHERE id = $1`,
+		id,
+	).Scan(
+		&event.ID, &event.Source, &event.EventType, &event.DeliveryID, &event.ContentType, &event.Payload, &event.TraceContext,
+		&event.Status, &eventErr, &event.Attempts, &event.ReceivedAt, &event.ProcessedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook event: %w", err)
+	}
+
+	if eventErr != nil {
+		event.Error = *eventErr
+	}
+	return event, nil
+}
+
+func (d *DB) UpdateWebhookEventStatus(ctx context.Context, event *model.WebhookEvent) error {
+	var eventErr *string
+	if event.Error != "" {
+		eventErr = &event.Error
+	}
+
+	err := d.pool.QueryRow(ctx,
+		`UPDATE webhook_events
+		SET status = $2, error = $3, attempts = attempts + 1,
+			processed_at = CASE WHEN $2 = $4 THEN now() ELSE processed_at END
+		WHERE id = $1
+		RETURNING attempts, processed_at`,
+		event.ID, event.Status, eventErr, model.WebhookStatusProcessed,
+	).Scan(&event.Attempts, &event.ProcessedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook event: %w", err)
+	}
+	return nil
+}
@@ -1,5 +1,15 @@
 package database
 
+import (
+	"context"
+
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
 type Student interface {
-	CreateStudent()
+	CreateStudent(ctx context.Context, student *model.Student) error
+	SearchStudents(ctx context.Context, filter *model.SearchStudentsRequest) ([]*model.Student, error)
+	// ReencryptStudents moves up to limit rows sealed with old keys to the
+	// active key and returns how many it moved.
+	ReencryptStudents(ctx context.Context, limit int) (int, error)
 }
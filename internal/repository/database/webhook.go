@@ -0,0 +1,15 @@
+package database
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Webhook interface {
+	CreateWebhookEvent(ctx context.Context, event *model.WebhookEvent) error
+	GetWebhookEvent(ctx context.Context, id uuid.UUID) (*model.WebhookEvent, error)
+	// UpdateWebhookEventStatus records the outcome of a dispatch attempt.
+	UpdateWebhookEventStatus(ctx context.Context, event *model.WebhookEvent) error
+}
@@ -6,6 +6,9 @@ import (
 	"github.com/shanto-323/backend-scaffold/internal/repository/cache"
 	"github.com/shanto-323/backend-scaffold/internal/repository/database"
 	"github.com/shanto-323/backend-scaffold/internal/repository/database/postgres"
+	"github.com/shanto-323/backend-scaffold/internal/repository/session"
+	logs "github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/metrics"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -16,16 +19,19 @@ type Repository struct {
 
 	DatabaseDriver database.Driver
 	CacheProvider  cache.Provider
+	SessionStore   session.Store
 }
 
-func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer) (*Repository, error) {
+func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer, metrics *metrics.Metrics) (*Repository, error) {
 
-	db, err := postgres.New(config, logger, tracer)
+	dbLogger := logs.Component(logs.ComponentDatabase)
+	db, err := postgres.New(config, &dbLogger, tracer)
 	if err != nil {
 		return nil, err
 	}
 
-	cache, err := cache.New(config, logger, tracer)
+	cacheLogger := logs.Component(logs.ComponentCache)
+	cache, err := cache.New(config, &cacheLogger, tracer, metrics)
 	if err != nil {
 		_ = db.Close()
 		return nil, err
@@ -38,6 +44,7 @@ func New(config *config.Config, logger *zerolog.Logger, tracer trace.Tracer) (*R
 
 		DatabaseDriver: db,
 		CacheProvider:  cache,
+		SessionStore:   session.New(cache),
 	}, nil
 }
 
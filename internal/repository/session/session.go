@@ -0,0 +1,123 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shanto-323/backend-scaffold/internal/repository/cache"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+// ErrNotFound is returned when the session does not exist or has expired.
+var ErrNotFound = errors.New("session: not found")
+
+const (
+	sessionKeyPrefix     = "session:"
+	userSessionKeyPrefix = "user_sessions:"
+)
+
+// Store keeps sessions in the cache. Each session expires on its own, the
+// per user index only lists session ids and is pruned while listing.
+type Store interface {
+	Save(ctx context.Context, session *model.Session, ttl time.Duration) error
+	Get(ctx context.Context, id string) (*model.Session, error)
+	List(ctx context.Context, userID string) ([]*model.Session, error)
+	Delete(ctx context.Context, userID string, ids ...string) error
+}
+
+type store struct {
+	cache cache.Provider
+}
+
+func New(provider cache.Provider) Store {
+	return &store{
+		cache: provider,
+	}
+}
+
+// Save creates or refreshes the session and extends its expiry to ttl.
+func (s *store) Save(ctx context.Context, session *model.Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	if err := s.cache.Set(ctx, sessionKeyPrefix+session.ID, data, ttl); err != nil {
+		return fmt.Errorf("failed to store session: %w", err)
+	}
+
+	userKey := userSessionKeyPrefix + session.UserID
+	if err := s.cache.SAdd(ctx, userKey, session.ID); err != nil {
+		return fmt.Errorf("failed to index session: %w", err)
+	}
+
+	// The index lives as long as the longest session in it, a session with a
+	// shorter expiry must not cut it short.
+	return s.cache.ExtendExpire(ctx, userKey, time.Until(session.ExpiresAt))
+}
+
+func (s *store) Get(ctx context.Context, id string) (*model.Session, error) {
+	data, err := s.cache.Get(ctx, sessionKeyPrefix+id)
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+
+	session := &model.Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return session, nil
+}
+
+func (s *store) List(ctx context.Context, userID string) ([]*model.Session, error) {
+	userKey := userSessionKeyPrefix + userID
+
+	ids, err := s.cache.SMembers(ctx, userKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*model.Session, 0, len(ids))
+	var expired []string
+	for _, id := range ids {
+		session, err := s.Get(ctx, id)
+		if errors.Is(err, ErrNotFound) {
+			expired = append(expired, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 0 {
+		if err := s.cache.SRem(ctx, userKey, expired...); err != nil {
+			return nil, fmt.Errorf("failed to prune sessions: %w", err)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (s *store) Delete(ctx context.Context, userID string, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKeyPrefix + id
+	}
+
+	if err := s.cache.Delete(ctx, keys...); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+	return s.cache.SRem(ctx, userSessionKeyPrefix+userID, ids...)
+}
@@ -0,0 +1,80 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// From converts any error returned by a handler into an HTTPError. Unknown
+// errors become a generic 500 so internal details never reach the client.
+func From(err error) *HTTPError {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	var echoErr *echo.HTTPError
+	if errors.As(err, &echoErr) {
+		return fromEchoError(echoErr)
+	}
+
+	if driverErr := FromDriverError(err); driverErr != nil {
+		return driverErr
+	}
+
+	return NewInternalServerError()
+}
+
+// FromDriverError maps well known pgx errors to client errors. It returns nil
+// when the error is not one of them.
+func FromDriverError(err error) *HTTPError {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return NewNotFoundError("Resource not found", false, nil)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		code := "ALREADY_EXISTS"
+		return NewConflictError("Resource already exists", false, &code)
+	case pgSerializationFailure, pgDeadlockDetected:
+		code := "CONCURRENT_UPDATE"
+		conflict := NewConflictError("Resource was modified concurrently", false, &code)
+		conflict.Action = &Action{
+			Type:    ActionTypeRetry,
+			Message: "The request can be retried",
+		}
+		return conflict
+	}
+
+	return nil
+}
+
+func fromEchoError(err *echo.HTTPError) *HTTPError {
+	message := http.StatusText(err.Code)
+	if msg, ok := err.Message.(string); ok && err.Code < http.StatusInternalServerError {
+		message = msg
+	}
+
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(err.Code)),
+		Message:  message,
+		Status:   err.Code,
+		Override: false,
+	}
+}
@@ -5,12 +5,19 @@ import "strings"
 type FieldError struct {
 	Field string `json:"field"`
 	Error string `json:"error"`
+	// Label is the field name in the language of the response.
+	Label string `json:"label,omitempty"`
+	// Tag and Param identify the failed rule so the message can be
+	// localized, e.g. "min.string" and "3".
+	Tag   string `json:"-"`
+	Param string `json:"-"`
 }
 
 type ActionType string
 
 const (
 	ActionTypeRedirect ActionType = "redirect"
+	ActionTypeRetry    ActionType = "retry"
 )
 
 type Action struct {
@@ -0,0 +1,126 @@
+package errs
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem is the RFC 9457 representation of an HTTPError. Code, Errors,
+// Action and RequestID are extension members.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Action    *Action      `json:"action,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+}
+
+// ProblemType documents one stable problem type. Its URI is the configured
+// base URI followed by the slug and must never change once published.
+type ProblemType struct {
+	Code        string `json:"code"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+var (
+	problemTypesMu sync.RWMutex
+	problemTypes   = map[string]ProblemType{}
+)
+
+func init() {
+	for _, t := range []ProblemType{
+		{Status: http.StatusBadRequest, Description: "The request is malformed or failed validation, see errors for the offending fields."},
+		{Status: http.StatusUnauthorized, Description: "The request lacks valid authentication credentials."},
+		{Status: http.StatusForbidden, Description: "The caller is authenticated but not allowed to perform the request."},
+		{Status: http.StatusNotFound, Description: "The requested resource does not exist."},
+		{Status: http.StatusMethodNotAllowed, Description: "The route does not support the request method."},
+		{Status: http.StatusConflict, Description: "The request conflicts with the current state of the resource."},
+		{Status: http.StatusRequestEntityTooLarge, Description: "The request body exceeds the route body limit."},
+		{Status: http.StatusUnsupportedMediaType, Description: "The request Content-Type is not accepted by the route."},
+		{Status: http.StatusTooManyRequests, Description: "The caller exceeded the rate limit."},
+		{Status: http.StatusInternalServerError, Description: "An unexpected error occurred on the server."},
+		{Status: http.StatusServiceUnavailable, Description: "The service can not handle the request right now."},
+		{Status: http.StatusGatewayTimeout, Description: "The request did not complete within its deadline."},
+		{Code: "ALREADY_EXISTS", Title: "Already Exists", Status: http.StatusConflict, Description: "A resource with the same unique attributes already exists."},
+		{Code: "TENANT_REQUIRED", Title: "Tenant Required", Status: http.StatusBadRequest, Description: "The request must identify its tenant through the token, the tenant header or the subdomain."},
+		{Code: "INVALID_TENANT", Title: "Invalid Tenant", Status: http.StatusBadRequest, Description: "The tenant identifier is malformed."},
+		{Code: "CSRF_TOKEN_INVALID", Title: "CSRF Token Invalid", Status: http.StatusForbidden, Description: "Unsafe requests authenticated by a session cookie must send the CSRF cookie value in the CSRF header."},
+		{Code: "IP_NOT_ALLOWED", Title: "IP Not Allowed", Status: http.StatusForbidden, Description: "The route is not reachable from the client address."},
+		{Code: "WEBHOOK_SIGNATURE_INVALID", Title: "Webhook Signature Invalid", Status: http.StatusUnauthorized, Description: "The webhook signature does not match the payload and timestamp for any secret of the source."},
+		{Code: "WEBHOOK_TIMESTAMP_OUT_OF_RANGE", Title: "Webhook Timestamp Out Of Range", Status: http.StatusUnauthorized, Description: "The signed webhook timestamp is missing or too far from the server time."},
+		{Code: "WEBHOOK_REPLAYED", Title: "Webhook Replayed", Status: http.StatusConflict, Description: "The webhook delivery was already received and is not processed again."},
+		{Code: "CONCURRENT_UPDATE", Title: "Concurrent Update", Status: http.StatusConflict, Description: "The resource was modified concurrently, the request can be retried."},
+	} {
+		if t.Code == "" {
+			t.Title = http.StatusText(t.Status)
+			t.Code = MakeUpperCaseWithUnderscores(t.Title)
+		}
+		RegisterProblemType(t)
+	}
+}
+
+// RegisterProblemType adds or replaces the problem type for t.Code. The slug
+// is derived from the code when empty.
+func RegisterProblemType(t ProblemType) {
+	if t.Slug == "" {
+		t.Slug = strings.ToLower(strings.ReplaceAll(t.Code, "_", "-"))
+	}
+
+	problemTypesMu.Lock()
+	defer problemTypesMu.Unlock()
+	problemTypes[t.Code] = t
+}
+
+func LookupProblemType(code string) (ProblemType, bool) {
+	problemTypesMu.RLock()
+	defer problemTypesMu.RUnlock()
+	t, ok := problemTypes[code]
+	return t, ok
+}
+
+// ProblemTypes returns every registered problem type ordered by code.
+func ProblemTypes() []ProblemType {
+	problemTypesMu.RLock()
+	defer problemTypesMu.RUnlock()
+
+	types := make([]ProblemType, 0, len(problemTypes))
+	for _, t := range problemTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Code < types[j].Code })
+	return types
+}
+
+// Problem renders the error as RFC 9457 problem details. Codes without a
+// registered type use "about:blank" as the spec recommends.
+func (e *HTTPError) Problem(baseURI, instance, requestID string) *Problem {
+	problem := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(e.Status),
+		Status:    e.Status,
+		Detail:    e.Message,
+		Instance:  instance,
+		Code:      e.Code,
+		Errors:    e.Errors,
+		Action:    e.Action,
+		RequestID: requestID,
+	}
+
+	if t, ok := LookupProblemType(e.Code); ok {
+		problem.Type = baseURI + t.Slug
+		problem.Title = t.Title
+	}
+
+	return problem
+}
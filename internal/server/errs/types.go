@@ -52,6 +52,48 @@ func NewNotFoundError(message string, override bool, code *string) *HTTPError {
 	}
 }
 
+func NewConflictError(message string, override bool, code *string) *HTTPError {
+	formattedCode := MakeUpperCaseWithUnderscores(http.StatusText(http.StatusConflict))
+
+	if code != nil {
+		formattedCode = *code
+	}
+
+	return &HTTPError{
+		Code:     formattedCode,
+		Message:  message,
+		Status:   http.StatusConflict,
+		Override: override,
+	}
+}
+
+func NewUnsupportedMediaTypeError(message string) *HTTPError {
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusUnsupportedMediaType)),
+		Message:  message,
+		Status:   http.StatusUnsupportedMediaType,
+		Override: false,
+	}
+}
+
+func NewServiceUnavailableError(message string) *HTTPError {
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusServiceUnavailable)),
+		Message:  message,
+		Status:   http.StatusServiceUnavailable,
+		Override: false,
+	}
+}
+
+func NewGatewayTimeoutError(message string) *HTTPError {
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusGatewayTimeout)),
+		Message:  message,
+		Status:   http.StatusGatewayTimeout,
+		Override: false,
+	}
+}
+
 func NewInternalServerError() *HTTPError {
 	return &HTTPError{
 		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusInternalServerError)),
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
+	"github.com/shanto-323/backend-scaffold/internal/service"
+	"github.com/shanto-323/backend-scaffold/internal/service/auth"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Auth struct {
+	s  *server.Server
+	sr *service.Services
+}
+
+func NewAuth(s *server.Server, sr *service.Services) *Auth {
+	return &Auth{
+		s:  s,
+		sr: sr,
+	}
+}
+
+// Login sends the browser to the identity provider. The state is also kept
+// in a signed cookie so the callback only completes in this browser.
+func (a *Auth) Login(c echo.Context) error {
+	return HandleRedirect(
+		func(c echo.Context, payload *model.OIDCLoginRequest) (string, error) {
+			authURL, state, err := a.sr.AuthService.LoginURL(c.Request().Context())
+			if err != nil {
+				return "", err
+			}
+
+			middleware.SetLoginStateCookie(c, &a.s.Config.Session, a.s.Config.Primary.SecretKey, state, auth.LoginStateTTL)
+			return authURL, nil
+		},
+		http.StatusFound,
+		&model.OIDCLoginRequest{},
+	)(c)
+}
+
+// Callback completes the login, sets the session cookies and sends the
+// browser on to the post login page.
+func (a *Auth) Callback(c echo.Context) error {
+	return HandleRedirect(
+		func(c echo.Context, payload *model.OIDCCallbackRequest) (string, error) {
+			if payload.Error != "" {
+				a.s.Logger.Warn().
+					Str("request_id", middleware.GetRequestID(c)).
+					Str("error", payload.Error).
+					Str("error_description", payload.ErrorDescription).
+					Msg("identity provider rejected login")
+				return "", errs.NewUnauthorizedError("Login failed", false)
+			}
+
+			// Without this a forged callback would log the victim into the
+			// attacker's account (login CSRF).
+			if !middleware.VerifyLoginStateCookie(c, &a.s.Config.Session, a.s.Config.Primary.SecretKey, payload.State) {
+				return "", errs.NewBadRequestError("Login was not started in this browser", false, nil, nil, nil)
+			}
+
+			sess, err := a.sr.AuthService.Callback(c.Request().Context(), auth.CallbackParams{
+				Code:      payload.Code,
+				State:     payload.State,
+				IP:        c.RealIP(),
+				UserAgent: c.Request().UserAgent(),
+			})
+			if err != nil {
+				return "", err
+			}
+
+			middleware.SetSessionCookies(c, &a.s.Config.Session, a.s.Config.Primary.SecretKey, sess)
+			return a.s.Config.OIDC.PostLoginRedirect, nil
+		},
+		http.StatusFound,
+		&model.OIDCCallbackRequest{},
+	)(c)
+}
@@ -67,6 +67,20 @@ func (h FileResponseHandler) GetOperation() string {
 
 // -------------------------- //
 
+type RedirectResponseHandler struct {
+	status int
+}
+
+func (h RedirectResponseHandler) Handle(c echo.Context, result any) error {
+	return c.Redirect(h.status, result.(string))
+}
+
+func (h RedirectResponseHandler) GetOperation() string {
+	return "handler_redirect"
+}
+
+// -------------------------- //
+
 func handleRequest[Req validation.Validatable](
 	c echo.Context,
 	req Req,
@@ -129,6 +143,20 @@ func handleRequest[Req validation.Validatable](
 	result, err := handler(c, req)
 	handlerDuration := time.Since(handlerStart)
 
+	if err != nil && middleware.IsCancelled(c.Request().Context(), err) {
+		logger.Warn().
+			Err(err).
+			Dur("handler_duration", handlerDuration).
+			Dur("total_duration", time.Since(start)).
+			Msg("handler execution cancelled")
+		span.SetAttributes(
+			attribute.String("handler.status", middleware.OutcomeCancelled),
+			attribute.Int64("handler.duration_ms", handlerDuration.Milliseconds()),
+		)
+
+		return err
+	}
+
 	if err != nil {
 		totalDuration := time.Since(start)
 
@@ -211,3 +239,17 @@ func HandleNoContent[Req validation.Validatable](
 		}, NoContentResponseHandler{status: status})
 	}
 }
+
+// -------------------------- //
+
+func HandleRedirect[Req validation.Validatable](
+	handler HandlerFunc[Req, string],
+	status int,
+	req Req,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(c, req, func(c echo.Context, req Req) (any, error) {
+			return handler(c, req)
+		}, RedirectResponseHandler{status: status})
+	}
+}
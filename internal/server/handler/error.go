@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/server/i18n"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
+)
+
+type errorResponse struct {
+	*errs.HTTPError
+	RequestID string `json:"request_id"`
+}
+
+// NewErrorHandler renders every error that reaches echo as an errs.HTTPError,
+// either as plain JSON or as RFC 9457 problem details.
+func NewErrorHandler(s *server.Server) echo.HTTPErrorHandler {
+	errorsConfig := s.Config.Server.Errors
+
+	return func(err error, c echo.Context) {
+		if c.Response().Committed {
+			return
+		}
+
+		logger := middleware.GetLogger(c)
+		httpErr := i18n.LocalizeError(middleware.GetLocale(c), errs.From(err))
+
+		if httpErr.Status >= http.StatusInternalServerError {
+			logger.Error().
+				Err(err).
+				Int("status", httpErr.Status).
+				Msg("request failed")
+		} else {
+			logger.Debug().
+				Err(err).
+				Int("status", httpErr.Status).
+				Str("code", httpErr.Code).
+				Msg("request rejected")
+		}
+
+		if httpErr.Action != nil && httpErr.Action.Type == errs.ActionTypeRetry {
+			c.Response().Header().Set("Retry-After", "1")
+		}
+
+		switch {
+		case c.Request().Method == http.MethodHead:
+			err = c.NoContent(httpErr.Status)
+		case wantsProblem(c, errorsConfig.Format):
+			err = writeProblem(c, httpErr.Problem(
+				errorsConfig.ProblemBaseURI,
+				c.Request().URL.Path,
+				middleware.GetRequestID(c),
+			))
+		default:
+			err = c.JSON(httpErr.Status, errorResponse{
+				HTTPError: httpErr,
+				RequestID: middleware.GetRequestID(c),
+			})
+		}
+
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to write error response")
+		}
+	}
+}
+
+func wantsProblem(c echo.Context, format string) bool {
+	if strings.Contains(c.Request().Header.Get(echo.HeaderAccept), errs.MIMEApplicationProblemJSON) {
+		return true
+	}
+	return format == config.ErrorFormatProblem
+}
+
+func writeProblem(c echo.Context, problem *errs.Problem) error {
+	data, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(problem.Status, errs.MIMEApplicationProblemJSON, data)
+}
@@ -6,13 +6,25 @@ import (
 )
 
 type Handlers struct {
-	HealthHandler  *HealthHandler
-	StudentHandler *Student
+	HealthHandler   *HealthHandler
+	StudentHandler  *Student
+	ProblemHandler  *ProblemHandler
+	SessionHandler  *Session
+	AuthHandler     *Auth
+	WebhookHandler  *Webhook
+	MetricsHandler  *MetricsHandler
+	LogLevelHandler *LogLevel
 }
 
 func New(s *server.Server, sr *service.Services) *Handlers {
 	return &Handlers{
-		HealthHandler:  NewHealthHandler(s),
-		StudentHandler: NewStudent(s, sr),
+		HealthHandler:   NewHealthHandler(s),
+		StudentHandler:  NewStudent(s, sr),
+		ProblemHandler:  NewProblemHandler(s),
+		SessionHandler:  NewSession(s, sr),
+		AuthHandler:     NewAuth(s, sr),
+		WebhookHandler:  NewWebhook(s, sr),
+		MetricsHandler:  NewMetricsHandler(s),
+		LogLevelHandler: NewLogLevel(s),
 	}
 }
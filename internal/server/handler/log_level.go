@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
+	"github.com/shanto-323/backend-scaffold/model"
+	logs "github.com/shanto-323/backend-scaffold/pkg/logger"
+)
+
+type LogLevel struct {
+	s *server.Server
+}
+
+func NewLogLevel(s *server.Server) *LogLevel {
+	return &LogLevel{
+		s: s,
+	}
+}
+
+// List returns the effective level of every log component.
+func (l *LogLevel) List(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.GetLogLevelsRequest) (*model.LogLevels, error) {
+			return &model.LogLevels{Levels: logs.CurrentLevels()}, nil
+		},
+		http.StatusOK,
+		&model.GetLogLevelsRequest{},
+	)(c)
+}
+
+// Update changes a level at runtime, the change is audit logged with the
+// calling user.
+func (l *LogLevel) Update(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.UpdateLogLevelRequest) (*model.LogLevels, error) {
+			component := payload.Component
+			if component == "" {
+				component = logs.ComponentGlobal
+			}
+
+			err := logs.SetLevel(logs.Change{
+				Component:   component,
+				Level:       payload.Level,
+				RevertAfter: payload.RevertDuration(),
+				Actor:       middleware.GetUserID(c),
+				Source:      "api",
+			})
+			if err != nil {
+				if errors.Is(err, logs.ErrUnknownComponent) {
+					return nil, errs.NewBadRequestError("Unknown log component", false, nil, []errs.FieldError{
+						{Field: "component", Error: err.Error()},
+					}, nil)
+				}
+				return nil, errs.NewBadRequestError(err.Error(), false, nil, []errs.FieldError{
+					{Field: "level", Error: err.Error()},
+				}, nil)
+			}
+
+			return &model.LogLevels{Levels: logs.CurrentLevels()}, nil
+		},
+		http.StatusOK,
+		&model.UpdateLogLevelRequest{},
+	)(c)
+}
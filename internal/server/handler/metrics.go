@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+)
+
+type MetricsHandler struct {
+	handler http.Handler
+}
+
+func NewMetricsHandler(s *server.Server) *MetricsHandler {
+	return &MetricsHandler{
+		handler: s.Metrics.Handler(),
+	}
+}
+
+// Scrape serves the metrics registry in the Prometheus text format.
+func (h *MetricsHandler) Scrape(c echo.Context) error {
+	h.handler.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
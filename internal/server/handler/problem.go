@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+)
+
+// ProblemHandler documents the problem types used in problem+json responses.
+type ProblemHandler struct {
+	server *server.Server
+}
+
+func NewProblemHandler(s *server.Server) *ProblemHandler {
+	return &ProblemHandler{
+		server: s,
+	}
+}
+
+func (h *ProblemHandler) ListProblemTypes(c echo.Context) error {
+	return c.JSON(http.StatusOK, errs.ProblemTypes())
+}
+
+func (h *ProblemHandler) GetProblemType(c echo.Context) error {
+	slug := c.Param("slug")
+	for _, t := range errs.ProblemTypes() {
+		if t.Slug == slug {
+			return c.JSON(http.StatusOK, t)
+		}
+	}
+	return errs.NewNotFoundError("Unknown problem type", false, nil)
+}
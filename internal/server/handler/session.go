@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
+	"github.com/shanto-323/backend-scaffold/internal/service"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Session struct {
+	s  *server.Server
+	sr *service.Services
+}
+
+func NewSession(s *server.Server, sr *service.Services) *Session {
+	return &Session{
+		s:  s,
+		sr: sr,
+	}
+}
+
+// List returns the active sessions of the current user.
+func (se *Session) List(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.ListSessionsRequest) ([]model.SessionInfo, error) {
+			sessions, err := se.sr.SessionService.List(c.Request().Context(), middleware.GetUserID(c))
+			if err != nil {
+				return nil, err
+			}
+
+			current := middleware.GetSessionID(c)
+			infos := make([]model.SessionInfo, len(sessions))
+			for i, sess := range sessions {
+				infos[i] = sess.Info(current)
+			}
+			return infos, nil
+		},
+		http.StatusOK,
+		&model.ListSessionsRequest{},
+	)(c)
+}
+
+// Revoke ends one of the current user's sessions, ending the current one
+// also clears its cookies.
+func (se *Session) Revoke(c echo.Context) error {
+	return HandleNoContent(
+		func(c echo.Context, payload *model.RevokeSessionRequest) error {
+			if err := se.sr.SessionService.Revoke(c.Request().Context(), middleware.GetUserID(c), payload.ID); err != nil {
+				return err
+			}
+
+			if payload.ID == middleware.GetSessionID(c) {
+				middleware.ClearSessionCookies(c, &se.s.Config.Session)
+			}
+			return nil
+		},
+		http.StatusNoContent,
+		&model.RevokeSessionRequest{},
+	)(c)
+}
+
+// RevokeOthers ends every session of the current user but the current one.
+func (se *Session) RevokeOthers(c echo.Context) error {
+	return HandleNoContent(
+		func(c echo.Context, payload *model.ListSessionsRequest) error {
+			return se.sr.SessionService.RevokeAll(c.Request().Context(), middleware.GetUserID(c), middleware.GetSessionID(c))
+		},
+		http.StatusNoContent,
+		&model.ListSessionsRequest{},
+	)(c)
+}
+
+// Logout ends the current session.
+func (se *Session) Logout(c echo.Context) error {
+	return HandleNoContent(
+		func(c echo.Context, payload *model.ListSessionsRequest) error {
+			err := se.sr.SessionService.Revoke(c.Request().Context(), middleware.GetUserID(c), middleware.GetSessionID(c))
+			middleware.ClearSessionCookies(c, &se.s.Config.Session)
+			return err
+		},
+		http.StatusNoContent,
+		&model.ListSessionsRequest{},
+	)(c)
+}
@@ -30,3 +30,14 @@ func (stud *Student) Create(c echo.Context) error {
 		&model.Student{},
 	)(c)
 }
+
+// Search finds students by exact phone or guardian contact.
+func (stud *Student) Search(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.SearchStudentsRequest) ([]*model.Student, error) {
+			return stud.sr.StudentService.Search(c.Request().Context(), payload)
+		},
+		http.StatusOK,
+		&model.SearchStudentsRequest{},
+	)(c)
+}
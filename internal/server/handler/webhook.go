@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
+	"github.com/shanto-323/backend-scaffold/internal/service"
+	"github.com/shanto-323/backend-scaffold/internal/service/webhook"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Webhook struct {
+	s  *server.Server
+	sr *service.Services
+}
+
+func NewWebhook(s *server.Server, sr *service.Services) *Webhook {
+	return &Webhook{
+		s:  s,
+		sr: sr,
+	}
+}
+
+// Receive accepts a delivery verified by VerifyWebhook.
+func (w *Webhook) Receive(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.ReceiveWebhookRequest) (*model.WebhookReceipt, error) {
+			source := middleware.GetWebhookSource(c)
+
+			event, err := w.sr.WebhookService.Receive(c.Request().Context(), webhook.ReceiveParams{
+				Source:      source,
+				DeliveryID:  middleware.GetWebhookDeliveryID(c),
+				EventType:   c.Request().Header.Get(w.s.Config.Webhooks.Sources[source].EventTypeHeader),
+				ContentType: c.Request().Header.Get(echo.HeaderContentType),
+				Payload:     middleware.GetWebhookPayload(c),
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return &model.WebhookReceipt{ID: event.ID, Status: event.Status}, nil
+		},
+		http.StatusAccepted,
+		&model.ReceiveWebhookRequest{},
+	)(c)
+}
+
+// Reprocess dispatches a stored event again, for events that failed or had
+// no handler when they arrived.
+func (w *Webhook) Reprocess(c echo.Context) error {
+	return Handle(
+		func(c echo.Context, payload *model.ReprocessWebhookRequest) (*model.WebhookEvent, error) {
+			return w.sr.WebhookService.Reprocess(c.Request().Context(), payload.ID)
+		},
+		http.StatusOK,
+		&model.ReprocessWebhookRequest{},
+	)(c)
+}
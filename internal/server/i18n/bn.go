@@ -0,0 +1,46 @@
+package i18n
+
+var bengali = map[string]string{
+	// Validation
+	"validation.failed":        "যাচাইকরণ ব্যর্থ হয়েছে",
+	"validation.required":      "অবশ্যই দিতে হবে",
+	"validation.min":           "কমপক্ষে {param} হতে হবে",
+	"validation.min.string":    "কমপক্ষে {param} অক্ষরের হতে হবে",
+	"validation.max":           "{param} এর বেশি হতে পারবে না",
+	"validation.max.string":    "{param} অক্ষরের বেশি হতে পারবে না",
+	"validation.oneof":         "এর মধ্যে একটি হতে হবে: {param}",
+	"validation.email":         "একটি বৈধ ইমেইল ঠিকানা হতে হবে",
+	"validation.e164":          "দেশের কোডসহ একটি বৈধ ফোন নম্বর হতে হবে",
+	"validation.uuid":          "একটি বৈধ UUID হতে হবে",
+	"validation.uuidList":      "কমা দিয়ে আলাদা করা বৈধ UUID-এর তালিকা হতে হবে",
+	"validation.dive":          "কিছু আইটেম অবৈধ",
+	"validation.default":       "{field}: {tag}",
+	"validation.default.param": "{field}: {tag}:{param}",
+
+	// Errors
+	"error.BAD_REQUEST":                    "অনুরোধটি সঠিক নয়",
+	"error.UNAUTHORIZED":                   "প্রমাণীকরণ প্রয়োজন",
+	"error.FORBIDDEN":                      "এই কাজটি করার অনুমতি আপনার নেই",
+	"error.NOT_FOUND":                      "খুঁজে পাওয়া যায়নি",
+	"error.METHOD_NOT_ALLOWED":             "এই মেথড অনুমোদিত নয়",
+	"error.CONFLICT":                       "অনুরোধটি বর্তমান অবস্থার সাথে সাংঘর্ষিক",
+	"error.REQUEST_ENTITY_TOO_LARGE":       "অনুরোধের আকার অনেক বড়",
+	"error.UNSUPPORTED_MEDIA_TYPE":         "এই কনটেন্ট টাইপ সমর্থিত নয়",
+	"error.TOO_MANY_REQUESTS":              "অনেক বেশি অনুরোধ, কিছুক্ষণ পরে আবার চেষ্টা করুন",
+	"error.INTERNAL_SERVER_ERROR":          "সার্ভারে একটি অপ্রত্যাশিত ত্রুটি হয়েছে",
+	"error.SERVICE_UNAVAILABLE":            "সেবাটি এই মুহূর্তে উপলব্ধ নয়",
+	"error.GATEWAY_TIMEOUT":                "অনুরোধটি নির্ধারিত সময়ের মধ্যে শেষ হয়নি",
+	"error.ALREADY_EXISTS":                 "এটি ইতিমধ্যে বিদ্যমান",
+	"error.CONCURRENT_UPDATE":              "একই সময়ে এটি পরিবর্তন করা হয়েছে, আবার চেষ্টা করুন",
+	"error.TENANT_REQUIRED":                "প্রতিষ্ঠান শনাক্ত করা যায়নি",
+	"error.INVALID_TENANT":                 "প্রতিষ্ঠানের শনাক্তকারী সঠিক নয়",
+	"error.CSRF_TOKEN_INVALID":             "CSRF টোকেন নেই অথবা সঠিক নয়",
+	"error.IP_NOT_ALLOWED":                 "এই ঠিকানা থেকে প্রবেশের অনুমতি নেই",
+	"error.WEBHOOK_SIGNATURE_INVALID":      "ওয়েবহুক স্বাক্ষর সঠিক নয়",
+	"error.WEBHOOK_TIMESTAMP_OUT_OF_RANGE": "ওয়েবহুকের সময় নেই অথবা মেয়াদোত্তীর্ণ",
+	"error.WEBHOOK_REPLAYED":               "এই ওয়েবহুক ইতিমধ্যে গ্রহণ করা হয়েছে",
+
+	// Fields
+	"field.name": "নাম",
+	"field.roll": "রোল",
+}
@@ -0,0 +1,46 @@
+package i18n
+
+var english = map[string]string{
+	// Validation
+	"validation.failed":        "Validation failed",
+	"validation.required":      "is required",
+	"validation.min":           "must be at least {param}",
+	"validation.min.string":    "must be at least {param} characters",
+	"validation.max":           "must not exceed {param}",
+	"validation.max.string":    "must not exceed {param} characters",
+	"validation.oneof":         "must be one of: {param}",
+	"validation.email":         "must be a valid email address",
+	"validation.e164":          "must be a valid phone number with country code",
+	"validation.uuid":          "must be a valid UUID",
+	"validation.uuidList":      "must be a comma-separated list of valid UUIDs",
+	"validation.dive":          "some items are invalid",
+	"validation.default":       "{field}: {tag}",
+	"validation.default.param": "{field}: {tag}:{param}",
+
+	// Errors
+	"error.BAD_REQUEST":                    "Bad request",
+	"error.UNAUTHORIZED":                   "Authentication is required",
+	"error.FORBIDDEN":                      "You are not allowed to perform this action",
+	"error.NOT_FOUND":                      "Resource not found",
+	"error.METHOD_NOT_ALLOWED":             "Method not allowed",
+	"error.CONFLICT":                       "The request conflicts with the current state of the resource",
+	"error.REQUEST_ENTITY_TOO_LARGE":       "Request body is too large",
+	"error.UNSUPPORTED_MEDIA_TYPE":         "Unsupported content type",
+	"error.TOO_MANY_REQUESTS":              "Too many requests, please try again later",
+	"error.INTERNAL_SERVER_ERROR":          "Internal server error",
+	"error.SERVICE_UNAVAILABLE":            "Service is temporarily unavailable",
+	"error.GATEWAY_TIMEOUT":                "The request did not complete in time",
+	"error.ALREADY_EXISTS":                 "Resource already exists",
+	"error.CONCURRENT_UPDATE":              "Resource was modified concurrently, please retry",
+	"error.TENANT_REQUIRED":                "Tenant could not be resolved",
+	"error.INVALID_TENANT":                 "Invalid tenant identifier",
+	"error.CSRF_TOKEN_INVALID":             "Missing or invalid CSRF token",
+	"error.IP_NOT_ALLOWED":                 "Access from this address is not allowed",
+	"error.WEBHOOK_SIGNATURE_INVALID":      "Webhook signature is invalid",
+	"error.WEBHOOK_TIMESTAMP_OUT_OF_RANGE": "Webhook timestamp is missing or expired",
+	"error.WEBHOOK_REPLAYED":               "Webhook delivery was already received",
+
+	// Fields
+	"field.name": "Name",
+	"field.roll": "Roll",
+}
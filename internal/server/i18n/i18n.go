@@ -0,0 +1,146 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"golang.org/x/text/language"
+)
+
+type localeKey struct{}
+
+// Fallback is used when no requested language is supported and for every
+// message missing from another catalog.
+var Fallback = language.English
+
+// catalogs holds the messages of every supported locale. Keys are
+// "validation.<tag>", "error.<errs code>" and "field.<field name>", values
+// may use the {field}, {param} and {tag} placeholders.
+var catalogs = map[language.Tag]map[string]string{
+	language.English: english,
+	language.Bengali: bengali,
+}
+
+var (
+	supported = supportedTags()
+	matcher   = language.NewMatcher(supported)
+)
+
+func supportedTags() []language.Tag {
+	// The fallback must come first, the matcher returns it when nothing matches.
+	tags := []language.Tag{Fallback}
+	for tag := range catalogs {
+		if tag != Fallback {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// Match picks the best supported locale for an Accept-Language header.
+func Match(acceptLanguage string) language.Tag {
+	requested, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(requested) == 0 {
+		return Fallback
+	}
+
+	_, index, confidence := matcher.Match(requested...)
+	if confidence == language.No {
+		return Fallback
+	}
+	return supported[index]
+}
+
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+func FromContext(ctx context.Context) language.Tag {
+	if locale, ok := ctx.Value(localeKey{}).(language.Tag); ok {
+		return locale
+	}
+	return Fallback
+}
+
+// Lookup returns the message for key in locale without falling back.
+func Lookup(locale language.Tag, key string) (string, bool) {
+	msg, ok := catalogs[locale][key]
+	return msg, ok
+}
+
+// Translate returns the message for key in locale, falling back to the
+// fallback locale and finally to the key itself.
+func Translate(locale language.Tag, key string, params map[string]string) string {
+	msg, ok := Lookup(locale, key)
+	if !ok {
+		if msg, ok = Lookup(Fallback, key); !ok {
+			return key
+		}
+	}
+
+	if len(params) == 0 {
+		return msg
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// ValidationMessage renders the message for a failed validator tag. Tags
+// without a translation fall back to "<field>: <tag>:<param>".
+func ValidationMessage(locale language.Tag, tag, field, param string) string {
+	params := map[string]string{"field": field, "param": param, "tag": tag}
+
+	key := "validation." + tag
+	if _, ok := Lookup(Fallback, key); ok {
+		return Translate(locale, key, params)
+	}
+	if param != "" {
+		return Translate(locale, "validation.default.param", params)
+	}
+	return Translate(locale, "validation.default", params)
+}
+
+// FieldLabel returns the display name of a request field.
+func FieldLabel(locale language.Tag, field string) string {
+	key := "field." + field
+	if label := Translate(locale, key, nil); label != key {
+		return label
+	}
+	return field
+}
+
+// LocalizeError returns a copy of e with its message and field errors in
+// locale. The specific English message is kept for the fallback locale and
+// whenever e.Override is set, since the handler chose it for the client;
+// otherwise other locales get the translated message of the error code.
+func LocalizeError(locale language.Tag, e *errs.HTTPError) *errs.HTTPError {
+	localized := e.WithMessage(e.Message)
+
+	if locale != Fallback && !e.Override {
+		key := "error." + e.Code
+		if len(e.Errors) > 0 {
+			key = "validation.failed"
+		}
+		if msg, ok := Lookup(locale, key); ok {
+			localized.Message = msg
+		}
+	}
+
+	if len(e.Errors) > 0 {
+		localized.Errors = make([]errs.FieldError, len(e.Errors))
+		for i, fieldErr := range e.Errors {
+			fieldErr.Label = FieldLabel(locale, fieldErr.Field)
+			if fieldErr.Tag != "" {
+				fieldErr.Error = ValidationMessage(locale, fieldErr.Tag, fieldErr.Field, fieldErr.Param)
+			}
+			localized.Errors[i] = fieldErr
+		}
+	}
+
+	return localized
+}
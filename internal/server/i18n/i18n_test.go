@@ -0,0 +1,54 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/server/i18n"
+	"golang.org/x/text/language"
+)
+
+func TestLocalizeErrorMessage(t *testing.T) {
+	translated, ok := i18n.Lookup(language.Bengali, "error.NOT_FOUND")
+	if !ok {
+		t.Fatal("no Bengali message for error.NOT_FOUND")
+	}
+
+	tests := []struct {
+		name   string
+		locale language.Tag
+		err    *errs.HTTPError
+		want   string
+	}{
+		{
+			name:   "fallback locale keeps the message",
+			locale: i18n.Fallback,
+			err:    errs.NewNotFoundError("student not found", false, nil),
+			want:   "student not found",
+		},
+		{
+			name:   "other locale translates the code",
+			locale: language.Bengali,
+			err:    errs.NewNotFoundError("student not found", false, nil),
+			want:   translated,
+		},
+		{
+			name:   "override keeps the handler message",
+			locale: language.Bengali,
+			err:    errs.NewNotFoundError("student 7 was archived", true, nil),
+			want:   "student 7 was archived",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := i18n.LocalizeError(tt.locale, tt.err)
+			if got.Message != tt.want {
+				t.Errorf("Message = %q, want %q", got.Message, tt.want)
+			}
+			if got == tt.err {
+				t.Error("LocalizeError returned its input, want a copy")
+			}
+		})
+	}
+}
@@ -1,11 +1,10 @@
 package middleware
 
 import (
-	"context"
-
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog"
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
 )
 
 const (
@@ -16,11 +15,14 @@ const (
 
 type ContextEnhancer struct {
 	s *server.Server
+	// logger is the http component logger, its level is set at runtime.
+	logger zerolog.Logger
 }
 
 func NewContextEnhancer(s *server.Server) *ContextEnhancer {
 	return &ContextEnhancer{
-		s: s,
+		s:      s,
+		logger: logger.Component(logger.ComponentHTTP),
 	}
 }
 
@@ -29,7 +31,7 @@ func (ce *ContextEnhancer) EnhanceContext() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			requestID := GetRequestID(c)
 
-			contextLogger := ce.s.Logger.With().
+			contextLogger := ce.logger.With().
 				Str("request_id", requestID).
 				Str("method", c.Request().Method).
 				Str("path", c.Path()).
@@ -44,10 +46,16 @@ func (ce *ContextEnhancer) EnhanceContext() echo.MiddlewareFunc {
 				contextLogger = contextLogger.With().Str("user_role", userRole).Logger()
 			}
 
-			c.Set(LoggerKey, &contextLogger)
+			if subject := GetClientCertSubject(c); subject != "" {
+				contextLogger = contextLogger.With().Str("client_cert_subject", subject).Logger()
+			}
 
-			ctx := context.WithValue(c.Request().Context(), LoggerKey, &contextLogger)
-			c.SetRequest(c.Request().WithContext(ctx))
+			// EnhanceTracing runs first, the span of the request is in ctx.
+			ctx := c.Request().Context()
+			contextLogger = logger.WithTraceContext(ctx, contextLogger)
+
+			c.Set(LoggerKey, &contextLogger)
+			c.SetRequest(c.Request().WithContext(contextLogger.WithContext(ctx)))
 
 			return next(c)
 		}
@@ -77,6 +85,13 @@ func GetLogger(c echo.Context) *zerolog.Logger {
 	return &logger
 }
 
+func GetUserRole(c echo.Context) string {
+	if userRole, ok := c.Get(UserRoleKey).(string); ok {
+		return userRole
+	}
+	return ""
+}
+
 func GetUserID(c echo.Context) string {
 	if userID, ok := c.Get(UserIDKey).(string); ok {
 		return userID
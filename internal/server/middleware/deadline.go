@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	OutcomeCancelled   = "cancelled"
+	OutcomeTimeout     = "timeout"
+	OutcomeUnavailable = "unavailable"
+)
+
+type Deadline struct {
+	s *server.Server
+}
+
+func NewDeadline(s *server.Server) *Deadline {
+	return &Deadline{
+		s: s,
+	}
+}
+
+// Timeout derives a deadline from the policy on the request context so it
+// reaches every pgx and redis call made with it. Exceeded deadlines become a
+// 504, or a 503 while the server shuts down or when the deadline was set
+// upstream of this middleware.
+func (d *Deadline) Timeout(policy config.TimeoutPolicy) echo.MiddlewareFunc {
+	timeout := d.s.Config.Server.Timeouts.Policy(policy)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if timeout <= 0 {
+				return next(c)
+			}
+
+			parent := c.Request().Context()
+			ctx, cancel := context.WithTimeout(parent, timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+
+			outcome, mapped := deadlineOutcome(parent, ctx, err, d.s.ShuttingDown())
+			switch outcome {
+			case "":
+				return err
+			case OutcomeCancelled:
+				GetLogger(c).Warn().
+					Str("operation", "deadline").
+					Str("outcome", OutcomeCancelled).
+					Msg("request cancelled by client")
+				return err
+			}
+
+			GetLogger(c).Warn().
+				Str("operation", "deadline").
+				Str("outcome", outcome).
+				Str("policy", string(policy)).
+				Dur("timeout", timeout).
+				Msg("request deadline exceeded")
+			trace.SpanFromContext(ctx).SetAttributes(
+				attribute.String("http.outcome", outcome),
+				attribute.String("http.timeout_policy", string(policy)),
+			)
+
+			if c.Response().Committed {
+				return err
+			}
+			return mapped
+		}
+	}
+}
+
+// deadlineOutcome classifies how a request under a deadline ended. The
+// error is what the client gets unless the response is already written.
+//   - the client went away: cancelled, err is kept
+//   - a deadline set before this middleware ran out: unavailable, 503
+//   - the policy deadline ran out while shutting down: unavailable, 503
+//   - the policy deadline ran out: timeout, 504
+func deadlineOutcome(parent, ctx context.Context, err error, shuttingDown bool) (string, error) {
+	switch {
+	case IsCancelled(parent, err):
+		return OutcomeCancelled, err
+	case errors.Is(parent.Err(), context.DeadlineExceeded):
+		return OutcomeUnavailable, errs.NewServiceUnavailableError("request deadline exceeded upstream")
+	case !errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return "", err
+	case shuttingDown:
+		return OutcomeUnavailable, errs.NewServiceUnavailableError("server is shutting down")
+	default:
+		return OutcomeTimeout, errs.NewGatewayTimeoutError("request deadline exceeded")
+	}
+}
+
+// IsCancelled reports whether the request was abandoned by the client rather
+// than failed by the server.
+func IsCancelled(ctx context.Context, err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled)
+}
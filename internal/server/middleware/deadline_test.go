@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+)
+
+func TestDeadlineOutcome(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+
+	tests := []struct {
+		name         string
+		parent       func() (context.Context, context.CancelFunc)
+		timeout      time.Duration
+		err          error
+		shuttingDown bool
+		wantOutcome  string
+		// wantErr is checked for errors passed through, wantStatus for
+		// mapped ones.
+		wantErr    error
+		wantStatus int
+	}{
+		{
+			name:    "finished in time",
+			parent:  background,
+			timeout: time.Minute,
+		},
+		{
+			name:    "handler error in time",
+			parent:  background,
+			timeout: time.Minute,
+			err:     handlerErr,
+			wantErr: handlerErr,
+		},
+		{
+			name: "client went away",
+			parent: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			timeout:     time.Minute,
+			err:         context.Canceled,
+			wantOutcome: OutcomeCancelled,
+			wantErr:     context.Canceled,
+		},
+		{
+			name:        "policy deadline exceeded",
+			parent:      background,
+			timeout:     time.Nanosecond,
+			err:         context.DeadlineExceeded,
+			wantOutcome: OutcomeTimeout,
+			wantStatus:  http.StatusGatewayTimeout,
+		},
+		{
+			name:         "policy deadline exceeded while shutting down",
+			parent:       background,
+			timeout:      time.Nanosecond,
+			err:          context.DeadlineExceeded,
+			shuttingDown: true,
+			wantOutcome:  OutcomeUnavailable,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name: "upstream deadline exceeded",
+			parent: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Nanosecond)
+			},
+			timeout:     time.Minute,
+			err:         context.DeadlineExceeded,
+			wantOutcome: OutcomeUnavailable,
+			wantStatus:  http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, cancelParent := tt.parent()
+			defer cancelParent()
+			ctx, cancel := context.WithTimeout(parent, tt.timeout)
+			defer cancel()
+			if tt.timeout < time.Millisecond {
+				<-ctx.Done()
+			}
+
+			outcome, err := deadlineOutcome(parent, ctx, tt.err, tt.shuttingDown)
+			if outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q", outcome, tt.wantOutcome)
+			}
+
+			if tt.wantStatus == 0 {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if status := errs.From(err).Status; status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func background() (context.Context, context.CancelFunc) {
+	return context.WithCancel(context.Background())
+}
@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
 )
 
 type Global struct {
@@ -16,9 +22,59 @@ func NewGlobal(s *server.Server) *Global {
 	}
 }
 
-func (g *Global) CROS() echo.MiddlewareFunc {
+func (g *Global) CORS() echo.MiddlewareFunc {
+	cfg := g.s.Config.Server
 	return middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins: g.s.Config.Server.CORSAllowedOrigins,
+		AllowOrigins:     cfg.CORSAllowedOrigins,
+		AllowMethods:     cfg.CORS.AllowMethods,
+		AllowHeaders:     cfg.CORS.AllowHeaders,
+		ExposeHeaders:    cfg.CORS.ExposeHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	})
+}
+
+func (g *Global) SecureHeaders() echo.MiddlewareFunc {
+	cfg := g.s.Config.Server.Security
+	return middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff:    cfg.ContentTypeNosniff,
+		XFrameOptions:         cfg.XFrameOptions,
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+		HSTSExcludeSubdomains: cfg.HSTSExcludeSubdomains,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		ReferrerPolicy:        cfg.ReferrerPolicy,
 	})
 }
 
+// BodyLimit rejects request bodies larger than limit (e.g. "512K", "2M").
+// An empty limit falls back to the configured server wide body limit.
+func (g *Global) BodyLimit(limit string) echo.MiddlewareFunc {
+	if limit == "" {
+		limit = g.s.Config.Server.Security.BodyLimit
+	}
+	return middleware.BodyLimit(limit)
+}
+
+// AllowContentTypes rejects requests carrying a body whose media type is not
+// one of types. When no types are given the configured list is used.
+func (g *Global) AllowContentTypes(types ...string) echo.MiddlewareFunc {
+	if len(types) == 0 {
+		types = g.s.Config.Server.Security.AllowedContentTypes
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.ContentLength == 0 || req.Method == http.MethodGet || req.Method == http.MethodHead {
+				return next(c)
+			}
+
+			mediaType, _, err := mime.ParseMediaType(req.Header.Get(echo.HeaderContentType))
+			if err != nil || !slices.Contains(types, mediaType) {
+				return errs.NewUnsupportedMediaTypeError("Content-Type must be one of: " + strings.Join(types, ", "))
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/repository/cache"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	idempotencyKeyPrefix    = "idempotency:"
+	idempotencyLockSuffix   = ":lock"
+	idempotencyDefaultTTL   = 24 * time.Hour
+	idempotencyLockTTL      = 30 * time.Second
+	idempotencyMaxKeyLength = 255
+)
+
+// idempotentResponse is the first response stored for an Idempotency-Key.
+type idempotentResponse struct {
+	Fingerprint string              `json:"fingerprint"`
+	Status      int                 `json:"status"`
+	Header      map[string][]string `json:"header"`
+	Body        []byte              `json:"body"`
+}
+
+type Idempotency struct {
+	s *server.Server
+}
+
+func NewIdempotency(s *server.Server) *Idempotency {
+	return &Idempotency{
+		s: s,
+	}
+}
+
+// Idempotent replays the stored response for requests that repeat an
+// Idempotency-Key. A retry with a different body is rejected with 409, as is
+// a duplicate arriving while the first request is still in flight.
+func (i *Idempotency) Idempotent() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				return next(c)
+			}
+
+			if len(key) > idempotencyMaxKeyLength {
+				return errs.NewBadRequestError("Idempotency-Key is too long", false, nil, nil, nil)
+			}
+
+			logger := GetLogger(c).With().
+				Str("operation", "idempotency").
+				Str("idempotency_key", key).
+				Logger()
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					return httpErr
+				}
+				return errs.NewBadRequestError("failed to read request body", false, nil, nil, nil)
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			// Releasing the lock and storing the response must outlive a client
+			// that disconnects mid-request.
+			ctx := context.WithoutCancel(c.Request().Context())
+			provider := i.s.Repository.CacheProvider
+			storeKey := idempotencyKeyPrefix + c.Request().Method + ":" + c.Path() + ":" + GetUserID(c) + ":" + key
+			fingerprint := requestFingerprint(c, body)
+
+			stored, err := i.load(c, storeKey)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to load idempotent response")
+				return errs.NewInternalServerError()
+			}
+			if stored != nil {
+				return i.replay(c, stored, fingerprint)
+			}
+
+			// The owner token is ours alone, a request id can be chosen by the client.
+			lockKey := storeKey + idempotencyLockSuffix
+			lockOwner := []byte(uuid.NewString())
+			acquired, err := provider.SetNX(ctx, lockKey, lockOwner, idempotencyLockTTL)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to acquire idempotency lock")
+				return errs.NewInternalServerError()
+			}
+			if !acquired {
+				return errs.NewConflictError("A request with this Idempotency-Key is already in progress", false, nil)
+			}
+			defer func() {
+				// A handler outliving the lock ttl must not release the lock of
+				// the request that took it over.
+				released, err := provider.DeleteIfEqual(ctx, lockKey, lockOwner)
+				if err != nil {
+					logger.Error().Err(err).Msg("failed to release idempotency lock")
+				} else if !released {
+					logger.Warn().Dur("lock_ttl", idempotencyLockTTL).Msg("idempotency lock expired before the request completed")
+				}
+			}()
+
+			// The first request may have completed between the lookup and the lock.
+			stored, err = i.load(c, storeKey)
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to load idempotent response")
+				return errs.NewInternalServerError()
+			}
+			if stored != nil {
+				return i.replay(c, stored, fingerprint)
+			}
+
+			recorder := &bodyRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				return err
+			}
+
+			// Server errors are not stored so the client can retry them.
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				return nil
+			}
+
+			header := c.Response().Header().Clone()
+			header.Del(RequestIDHeader)
+
+			data, err := json.Marshal(idempotentResponse{
+				Fingerprint: fingerprint,
+				Status:      status,
+				Header:      header,
+				Body:        recorder.body.Bytes(),
+			})
+			if err != nil {
+				logger.Error().Err(err).Msg("failed to encode idempotent response")
+				return nil
+			}
+
+			if err := provider.Set(ctx, storeKey, data, i.ttl()); err != nil {
+				logger.Error().Err(err).Msg("failed to store idempotent response")
+			}
+
+			return nil
+		}
+	}
+}
+
+func (i *Idempotency) load(c echo.Context, key string) (*idempotentResponse, error) {
+	data, err := i.s.Repository.CacheProvider.Get(c.Request().Context(), key)
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &idempotentResponse{}
+	if err := json.Unmarshal(data, stored); err != nil {
+		return nil, err
+	}
+	return stored, nil
+}
+
+func (i *Idempotency) replay(c echo.Context, stored *idempotentResponse, fingerprint string) error {
+	if stored.Fingerprint != fingerprint {
+		return errs.NewConflictError("Idempotency-Key was already used with a different request body", false, nil)
+	}
+
+	header := c.Response().Header()
+	for name, values := range stored.Header {
+		header[name] = values
+	}
+	header.Set(IdempotencyReplayedHeader, "true")
+
+	GetLogger(c).Info().
+		Str("operation", "idempotency").
+		Int("status", stored.Status).
+		Msg("replaying idempotent response")
+
+	c.Response().WriteHeader(stored.Status)
+	_, err := c.Response().Write(stored.Body)
+	return err
+}
+
+func (i *Idempotency) ttl() time.Duration {
+	if i.s.Config.Server.IdempotencyTTL > 0 {
+		return time.Duration(i.s.Config.Server.IdempotencyTTL) * time.Second
+	}
+	return idempotencyDefaultTTL
+}
+
+func requestFingerprint(c echo.Context, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request().Method))
+	hash.Write([]byte(c.Request().URL.RequestURI()))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// bodyRecorder copies everything written to the response so it can be stored.
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
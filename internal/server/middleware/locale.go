@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server/i18n"
+	"golang.org/x/text/language"
+)
+
+const (
+	LocaleKey = "locale"
+
+	AcceptLanguageHeader  = "Accept-Language"
+	ContentLanguageHeader = "Content-Language"
+)
+
+// Localize negotiates the response language from Accept-Language and stores
+// it in the echo and request contexts.
+func Localize() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := i18n.Match(c.Request().Header.Get(AcceptLanguageHeader))
+
+			c.Set(LocaleKey, locale)
+			c.SetRequest(c.Request().WithContext(i18n.WithLocale(c.Request().Context(), locale)))
+
+			c.Response().Header().Set(ContentLanguageHeader, locale.String())
+			c.Response().Header().Add(echo.HeaderVary, AcceptLanguageHeader)
+
+			return next(c)
+		}
+	}
+}
+
+func GetLocale(c echo.Context) language.Tag {
+	if locale, ok := c.Get(LocaleKey).(language.Tag); ok {
+		return locale
+	}
+	return i18n.Fallback
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+)
+
+// unmatchedRoute labels requests no route matched, raw paths would give
+// every scanner probe its own series.
+const unmatchedRoute = "unmatched"
+
+type Metrics struct {
+	s *server.Server
+}
+
+func NewMetrics(s *server.Server) *Metrics {
+	return &Metrics{
+		s: s,
+	}
+}
+
+// RecordMetrics records rate, errors and duration per route template. A
+// panic passing through is recorded as the 500 Recover turns it into.
+func (m *Metrics) RecordMetrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			done := m.s.Metrics.StartRequest()
+
+			// Deferred so the in flight gauge is released even on a panic.
+			panicking := true
+			defer func() {
+				// Errors are rendered by the error handler after the middleware
+				// chain, the response does not carry their status yet.
+				status := c.Response().Status
+				switch {
+				case panicking:
+					status = http.StatusInternalServerError
+				case err != nil && !c.Response().Committed:
+					status = errs.From(err).Status
+				}
+
+				route := c.Path()
+				if route == "" || errors.Is(err, echo.ErrNotFound) || errors.Is(err, echo.ErrMethodNotAllowed) {
+					route = unmatchedRoute
+				}
+
+				done(route, c.Request().Method, status)
+			}()
+
+			err = next(c)
+			panicking = false
+			return err
+		}
+	}
+}
@@ -7,6 +7,15 @@ type Middlewares struct {
 	*RateLimit
 	*ContextEnhancer
 	*Tracer
+	*Idempotency
+	*Deadline
+	*Recovery
+	*Tenant
+	*Session
+	*Webhook
+	*Network
+	*MutualTLS
+	*Metrics
 }
 
 func New(s *server.Server) *Middlewares {
@@ -15,5 +24,14 @@ func New(s *server.Server) *Middlewares {
 		RateLimit:       NewRateLimit(s),
 		ContextEnhancer: NewContextEnhancer(s),
 		Tracer:          NewTracer(s),
+		Idempotency:     NewIdempotency(s),
+		Deadline:        NewDeadline(s),
+		Recovery:        NewRecovery(s),
+		Tenant:          NewTenant(s),
+		Session:         NewSession(s),
+		Webhook:         NewWebhook(s),
+		Network:         NewNetwork(s),
+		MutualTLS:       NewMutualTLS(s),
+		Metrics:         NewMetrics(s),
 	}
 }
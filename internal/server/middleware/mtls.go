@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+)
+
+const ClientCertSubjectKey = "client_cert_subject"
+
+type MutualTLS struct {
+	s *server.Server
+}
+
+func NewMutualTLS(s *server.Server) *MutualTLS {
+	return &MutualTLS{
+		s: s,
+	}
+}
+
+// ClientIdentity makes the subject of a verified client certificate the
+// caller identity, unless a session already identified the user. It must run
+// after LoadSession and before EnhanceContext.
+func (m *MutualTLS) ClientIdentity() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			state := c.Request().TLS
+			// Only chains verified against the client CA bundle count, a
+			// certificate the server did not verify identifies nobody.
+			if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+				return next(c)
+			}
+
+			subject := state.VerifiedChains[0][0].Subject.String()
+			c.Set(ClientCertSubjectKey, subject)
+			if GetUserID(c) == "" {
+				c.Set(UserIDKey, subject)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func GetClientCertSubject(c echo.Context) string {
+	if subject, ok := c.Get(ClientCertSubjectKey).(string); ok {
+		return subject
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+)
+
+type Network struct {
+	s *server.Server
+}
+
+func NewNetwork(s *server.Server) *Network {
+	return &Network{
+		s: s,
+	}
+}
+
+// IPExtractor returns the extractor behind c.RealIP(). Forwarding headers are
+// only believed when the peer is one of the trusted proxies, the private and
+// loopback ranges Echo trusts by default are not trusted implicitly.
+func (n *Network) IPExtractor() echo.IPExtractor {
+	cfg := n.s.Config.Server.Network
+
+	// Validated when the config was loaded.
+	proxies, _ := config.ParseCIDRs(cfg.TrustedProxies)
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range proxies {
+		options = append(options, echo.TrustIPRange(proxy))
+	}
+
+	if cfg.ClientIPHeader == config.ClientIPHeaderXRealIP {
+		return echo.ExtractIPFromRealIPHeader(options...)
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// IPAccess applies the named access list to the client IP. A list missing
+// from the config restricts nothing, which is logged at startup.
+func (n *Network) IPAccess(name string) echo.MiddlewareFunc {
+	list, ok := n.s.Config.Server.Network.AccessLists[name]
+	if !ok {
+		n.s.Logger.Warn().Str("access_list", name).Msg("access list is not configured, routes are reachable from any address")
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+
+	allow, _ := config.ParseCIDRs(list.Allow)
+	deny, _ := config.ParseCIDRs(list.Deny)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := net.ParseIP(c.RealIP())
+			if ip == nil || containsIP(deny, ip) || (len(allow) > 0 && !containsIP(allow, ip)) {
+				GetLogger(c).Warn().
+					Str("access_list", name).
+					Str("client_ip", c.RealIP()).
+					Msg("request rejected by access list")
+
+				forbidden := errs.NewForbiddenError("Access from this address is not allowed", false)
+				forbidden.Code = "IP_NOT_ALLOWED"
+				return forbidden
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
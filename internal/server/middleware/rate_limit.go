@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/shanto-323/backend-scaffold/config"
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"golang.org/x/time/rate"
 )
 
 type RateLimit struct {
@@ -18,11 +22,60 @@ func NewRateLimit(s *server.Server) *RateLimit {
 	}
 }
 
+// RateLimitHit limits requests per tenant and client IP. Tenants listed in
+// the tenancy overrides get their own rate, everyone else the server rate.
 func (r *RateLimit) RateLimitHit() echo.MiddlewareFunc {
 	return middleware.RateLimiterWithConfig(middleware.RateLimiterConfig{
-		Store: middleware.NewRateLimiterMemoryStore(10),
+		Store: newTenantRateLimiterStore(r.s.Config),
+		IdentifierExtractor: func(c echo.Context) (string, error) {
+			return GetTenantID(c) + rateLimitSeparator + c.RealIP(), nil
+		},
 		DenyHandler: func(context echo.Context, identifier string, err error) error {
 			return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
 		},
 	})
 }
+
+const rateLimitSeparator = "|"
+
+// tenantRateLimiterStore keeps one memory store per rate limit so tenants
+// with an override do not share buckets with the default rate.
+type tenantRateLimiterStore struct {
+	config *config.Config
+
+	mu     sync.Mutex
+	stores map[string]*middleware.RateLimiterMemoryStore
+}
+
+func newTenantRateLimiterStore(cfg *config.Config) *tenantRateLimiterStore {
+	return &tenantRateLimiterStore{
+		config: cfg,
+		stores: map[string]*middleware.RateLimiterMemoryStore{},
+	}
+}
+
+func (s *tenantRateLimiterStore) Allow(identifier string) (bool, error) {
+	tenantID, _, _ := strings.Cut(identifier, rateLimitSeparator)
+	return s.store(tenantID).Allow(identifier)
+}
+
+func (s *tenantRateLimiterStore) store(tenantID string) *middleware.RateLimiterMemoryStore {
+	// Tenants without an override share the default store.
+	if _, ok := s.config.Tenancy.Overrides[tenantID]; !ok {
+		tenantID = ""
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, ok := s.stores[tenantID]
+	if !ok {
+		limit, burst := s.config.RateLimitFor(tenantID)
+		store = middleware.NewRateLimiterMemoryStoreWithConfig(middleware.RateLimiterMemoryStoreConfig{
+			Rate:  rate.Limit(limit),
+			Burst: burst,
+		})
+		s.stores[tenantID] = store
+	}
+	return store
+}
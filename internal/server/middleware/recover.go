@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Recovery struct {
+	s      *server.Server
+	panics metric.Int64Counter
+}
+
+func NewRecovery(s *server.Server) *Recovery {
+	// A failure here only means the counter is not exported.
+	panics, err := s.MeterProvider.Meter.Int64Counter(
+		"http.server.panics",
+		metric.WithDescription("Number of panics recovered while serving HTTP requests"),
+	)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("failed to create panic counter")
+	}
+
+	return &Recovery{
+		s:      s,
+		panics: panics,
+	}
+}
+
+// Recover turns a panic in any later middleware or handler into a 500
+// response. The stack is logged with the request logger, or the server logger
+// when the panic happened before one was attached. EnhanceTracing records the
+// panic on the server span before passing it on.
+func (r *Recovery) Recover() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				// net/http uses this panic to abort a response on purpose.
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				logger := r.s.Logger
+				if requestLogger, ok := c.Get(LoggerKey).(*zerolog.Logger); ok {
+					logger = requestLogger
+				}
+				logger.Error().
+					Err(panicError(recovered)).
+					Str("operation", "recover").
+					Str("request_id", GetRequestID(c)).
+					Str("stack", string(debug.Stack())).
+					Msg("recovered from panic")
+
+				if r.panics != nil {
+					r.panics.Add(c.Request().Context(), 1, metric.WithAttributes(
+						attribute.String("http.method", c.Request().Method),
+						attribute.String("http.route", c.Path()),
+					))
+				}
+
+				err = errs.NewInternalServerError()
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// recordPanic marks the span failed with the panic and its stack.
+func recordPanic(span trace.Span, recovered any, stack []byte) {
+	span.RecordError(panicError(recovered), trace.WithAttributes(
+		attribute.String("exception.stacktrace", string(stack)),
+	))
+	span.SetStatus(codes.Error, "panic")
+}
+
+func panicError(recovered any) error {
+	if err, ok := recovered.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", recovered)
+}
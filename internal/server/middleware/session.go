@@ -0,0 +1,275 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/repository/session"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+const (
+	SessionIDKey       = "session_id"
+	SessionTenantIDKey = "session_tenant_id"
+
+	// LoginStateCookieName binds an OIDC login to the browser that started it.
+	LoginStateCookieName = "oidc_state"
+
+	// sessionTouchInterval limits how often the sliding expiry is written.
+	sessionTouchInterval = time.Minute
+)
+
+type Session struct {
+	s *server.Server
+}
+
+func NewSession(s *server.Server) *Session {
+	return &Session{
+		s: s,
+	}
+}
+
+// LoadSession authenticates requests carrying a valid session cookie and
+// slides its expiry. Unsafe methods must echo the CSRF token in the CSRF
+// header (double-submit). Requests without a session pass through, routes
+// needing a user add RequireSession. It must run before EnhanceContext so the
+// logger carries the user, and before ResolveTenant which binds the tenant of
+// the session.
+func (m *Session) LoadSession() echo.MiddlewareFunc {
+	cfg := m.s.Config.Session
+	store := m.s.Repository.SessionStore
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			cookie, err := c.Cookie(cfg.CookieName)
+			if err != nil {
+				return next(c)
+			}
+
+			id, ok := verifySigned(cookie.Value, m.s.Config.Primary.SecretKey)
+			if !ok {
+				ClearSessionCookies(c, &cfg)
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			sess, err := store.Get(ctx, id)
+			if errors.Is(err, session.ErrNotFound) {
+				ClearSessionCookies(c, &cfg)
+				return next(c)
+			}
+			if err != nil {
+				m.s.Logger.Error().Err(err).Str("request_id", GetRequestID(c)).Msg("failed to load session")
+				return errs.NewServiceUnavailableError("Session store unavailable")
+			}
+
+			now := time.Now().UTC()
+			if now.After(sess.ExpiresAt) {
+				_ = store.Delete(ctx, sess.UserID, sess.ID)
+				ClearSessionCookies(c, &cfg)
+				return next(c)
+			}
+
+			if !isSafeMethod(c.Request().Method) && !validCSRF(c, &cfg, sess) {
+				csrfErr := errs.NewForbiddenError("Missing or invalid CSRF token", false)
+				csrfErr.Code = "CSRF_TOKEN_INVALID"
+				return csrfErr
+			}
+
+			if now.Sub(sess.LastSeenAt) > sessionTouchInterval {
+				sess.LastSeenAt = now
+				if err := store.Save(ctx, sess, sessionTTL(&cfg, sess, now)); err != nil {
+					m.s.Logger.Error().Err(err).Str("request_id", GetRequestID(c)).Msg("failed to extend session")
+				}
+			}
+
+			c.Set(SessionIDKey, sess.ID)
+			c.Set(UserIDKey, sess.UserID)
+			c.Set(UserRoleKey, sess.UserRole)
+			c.Set(SessionTenantIDKey, sess.TenantID)
+
+			return next(c)
+		}
+	}
+}
+
+// RequireSession rejects requests that LoadSession did not authenticate.
+func (m *Session) RequireSession() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetSessionID(c) == "" {
+				return errs.NewUnauthorizedError("Authentication required", false)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAdmin rejects sessions without the configured admin role, use it
+// after RequireSession.
+func (m *Session) RequireAdmin() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetUserRole(c) != m.s.Config.Session.AdminRole {
+				return errs.NewForbiddenError("Admin role required", false)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireStaff rejects sessions without one of the configured staff roles,
+// use it after RequireSession.
+func (m *Session) RequireStaff() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !slices.Contains(m.s.Config.Session.StaffRoles, GetUserRole(c)) {
+				return errs.NewForbiddenError("Staff role required", false)
+			}
+			return next(c)
+		}
+	}
+}
+
+// SetSessionCookies writes the signed session cookie and the CSRF cookie the
+// browser has to echo in the CSRF header.
+func SetSessionCookies(c echo.Context, cfg *config.SessionConfig, secret string, sess *model.Session) {
+	c.SetCookie(&http.Cookie{
+		Name:     cfg.CookieName,
+		Value:    sign(sess.ID, secret),
+		Path:     "/",
+		Secure:   !cfg.InsecureCookies,
+		HttpOnly: true,
+		SameSite: cfg.SameSiteMode(),
+	})
+
+	// Readable by scripts on purpose, that is how the token gets into the header.
+	c.SetCookie(&http.Cookie{
+		Name:     cfg.CSRFCookieName,
+		Value:    sess.CSRFToken,
+		Path:     "/",
+		Secure:   !cfg.InsecureCookies,
+		HttpOnly: false,
+		SameSite: cfg.SameSiteMode(),
+	})
+}
+
+func ClearSessionCookies(c echo.Context, cfg *config.SessionConfig) {
+	for _, name := range []string{cfg.CookieName, cfg.CSRFCookieName} {
+		c.SetCookie(&http.Cookie{
+			Name:     name,
+			Value:    "",
+			Path:     "/",
+			MaxAge:   -1,
+			Secure:   !cfg.InsecureCookies,
+			HttpOnly: name == cfg.CookieName,
+			SameSite: cfg.SameSiteMode(),
+		})
+	}
+}
+
+// SetLoginStateCookie stores the signed OIDC state in the browser. It is Lax
+// so it survives the top level redirect back from the identity provider.
+func SetLoginStateCookie(c echo.Context, cfg *config.SessionConfig, secret, state string, ttl time.Duration) {
+	c.SetCookie(&http.Cookie{
+		Name:     LoginStateCookieName,
+		Value:    sign(state, secret),
+		Path:     "/",
+		MaxAge:   int(ttl.Seconds()),
+		Secure:   !cfg.InsecureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// VerifyLoginStateCookie reports whether the browser started the login with
+// this state and clears the cookie, it is good for one callback only.
+func VerifyLoginStateCookie(c echo.Context, cfg *config.SessionConfig, secret, state string) bool {
+	cookie, err := c.Cookie(LoginStateCookieName)
+
+	c.SetCookie(&http.Cookie{
+		Name:     LoginStateCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   !cfg.InsecureCookies,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if err != nil || state == "" {
+		return false
+	}
+	signed, ok := verifySigned(cookie.Value, secret)
+	return ok && subtle.ConstantTimeCompare([]byte(signed), []byte(state)) == 1
+}
+
+func GetSessionID(c echo.Context) string {
+	if sessionID, ok := c.Get(SessionIDKey).(string); ok {
+		return sessionID
+	}
+	return ""
+}
+
+// GetSessionTenantID returns the tenant the session was created for.
+func GetSessionTenantID(c echo.Context) string {
+	if tenantID, ok := c.Get(SessionTenantIDKey).(string); ok {
+		return tenantID
+	}
+	return ""
+}
+
+// sessionTTL is the idle timeout, cut short by the absolute expiry.
+func sessionTTL(cfg *config.SessionConfig, sess *model.Session, now time.Time) time.Duration {
+	ttl := cfg.IdleTimeout
+	if remaining := sess.ExpiresAt.Sub(now); remaining < ttl {
+		ttl = remaining
+	}
+	return ttl
+}
+
+func validCSRF(c echo.Context, cfg *config.SessionConfig, sess *model.Session) bool {
+	header := c.Request().Header.Get(cfg.CSRFHeader)
+	cookie, err := c.Cookie(cfg.CSRFCookieName)
+	if header == "" || err != nil {
+		return false
+	}
+
+	// The cookie and header must agree and belong to this session, so a
+	// token planted by a sibling subdomain is useless.
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(header), []byte(sess.CSRFToken)) == 1
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func sign(value, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(value))
+	return value + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func verifySigned(signed, secret string) (string, bool) {
+	value, _, ok := strings.Cut(signed, ".")
+	if !ok {
+		return "", false
+	}
+	return value, hmac.Equal([]byte(sign(value, secret)), []byte(signed))
+}
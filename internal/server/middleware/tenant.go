@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TenantIDKey = "tenant_id"
+
+type Tenant struct {
+	s *server.Server
+}
+
+func NewTenant(s *server.Server) *Tenant {
+	return &Tenant{
+		s: s,
+	}
+}
+
+// ResolveTenant finds the tenant the caller is bound to, from a bearer token
+// claim or the session, and stores it in the echo and request contexts. The
+// tenant header and the subdomain only select a tenant the caller is already
+// bound to, anything else is rejected. It runs on tenant scoped routes after
+// LoadSession and EnhanceContext, and adds the tenant to the request logger
+// and span itself.
+func (t *Tenant) ResolveTenant() echo.MiddlewareFunc {
+	cfg := t.s.Config.Tenancy
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !cfg.Enabled {
+				return next(c)
+			}
+
+			tenantID := t.fromClaim(c)
+			if sessionTenantID := GetSessionTenantID(c); sessionTenantID != "" {
+				if tenantID != "" && tenantID != sessionTenantID {
+					return errs.NewForbiddenError("Session belongs to another tenant", false)
+				}
+				tenantID = sessionTenantID
+			}
+
+			requested := strings.ToLower(strings.TrimSpace(c.Request().Header.Get(cfg.Header)))
+			if requested == "" {
+				requested = t.fromSubdomain(c)
+			}
+
+			// A caller can only select a tenant it is bound to.
+			if requested != "" && requested != tenantID {
+				if tenantID == "" {
+					return errs.NewForbiddenError("Tenant is not bound to the caller", false)
+				}
+				return errs.NewForbiddenError("Tenant does not match the caller", false)
+			}
+
+			if tenantID != "" && !tenant.Valid(tenantID) {
+				code := "INVALID_TENANT"
+				return errs.NewBadRequestError("Invalid tenant identifier", false, &code, nil, nil)
+			}
+
+			if tenantID == "" {
+				if cfg.Required {
+					code := "TENANT_REQUIRED"
+					return errs.NewBadRequestError("Tenant could not be resolved", false, &code, nil, nil)
+				}
+				return next(c)
+			}
+
+			contextLogger := GetLogger(c).With().Str("tenant_id", tenantID).Logger()
+			c.Set(LoggerKey, &contextLogger)
+
+			ctx := tenant.WithTenant(contextLogger.WithContext(c.Request().Context()), tenantID)
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("tenant_id", tenantID))
+
+			c.Set(TenantIDKey, tenantID)
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+func (t *Tenant) fromClaim(c echo.Context) string {
+	raw, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		return []byte(t.s.Config.Primary.SecretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return ""
+	}
+
+	tenantID, _ := claims[t.s.Config.Tenancy.Claim].(string)
+	return strings.ToLower(tenantID)
+}
+
+func (t *Tenant) fromSubdomain(c echo.Context) string {
+	baseDomain := t.s.Config.Tenancy.BaseDomain
+	if baseDomain == "" {
+		return ""
+	}
+
+	host := c.Request().Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	subdomain, ok := strings.CutSuffix(strings.ToLower(host), "."+baseDomain)
+	if !ok || strings.Contains(subdomain, ".") {
+		return ""
+	}
+	return subdomain
+}
+
+func GetTenantID(c echo.Context) string {
+	if tenantID, ok := c.Get(TenantIDKey).(string); ok {
+		return tenantID
+	}
+	return ""
+}
@@ -1,9 +1,16 @@
 package middleware
 
 import (
+	"net/http"
+	"runtime/debug"
+
 	"github.com/labstack/echo/v4"
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/pkg/tracer"
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Tracer struct {
@@ -19,11 +26,20 @@ func NewTracer(s *server.Server) *Tracer {
 func (t *Tracer) EnhanceTracing() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			ctx := c.Request().Context()
+			// Continue the caller's trace when it sent a traceparent, baggage
+			// comes along with it.
+			ctx := otel.GetTextMapPropagator().Extract(
+				c.Request().Context(),
+				propagation.HeaderCarrier(c.Request().Header),
+			)
 
-			ctx, span := t.s.TraceProvider.Tracer.Start(ctx, c.Path())
+			ctx, span := t.s.TraceProvider.Start(ctx, c.Path(), trace.WithSpanKind(trace.SpanKindServer))
 			c.SetRequest(c.Request().WithContext(ctx))
 
+			if traceResponse := tracer.TraceResponse(span.SpanContext()); traceResponse != "" {
+				c.Response().Header().Set(tracer.TraceResponseHeader, traceResponse)
+			}
+
 			attrs := []attribute.KeyValue{}
 			request_id := GetRequestID(c)
 			if request_id != "" {
@@ -44,17 +60,29 @@ func (t *Tracer) EnhanceTracing() echo.MiddlewareFunc {
 
 			span.SetAttributes(attrs...)
 
+			// Recover runs further out, by then this span would be over. A
+			// panic is recorded here and passed on.
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if recovered != http.ErrAbortHandler {
+						recordPanic(span, recovered, debug.Stack())
+						span.SetAttributes(attribute.Int("http.status_code", http.StatusInternalServerError))
+					}
+					span.End()
+					panic(recovered)
+				}
+				span.End()
+			}()
+
 			err := next(c)
-			if err != nil {
+			if IsCancelled(ctx, err) {
+				// The client went away, this is not a server side failure.
+				span.SetAttributes(attribute.String("http.outcome", OutcomeCancelled))
+			} else if err != nil {
 				span.RecordError(err)
 			}
 
 			span.SetAttributes(attribute.Int("http.status_code", c.Response().Status))
-			span.End()
-
-			if err := t.s.TraceProvider.ForceFlush(ctx); err != nil {
-				t.s.Logger.Error().Err(err).Msg("Failed to flush span")
-			}
 
 			return err
 		}
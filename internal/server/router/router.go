@@ -1,6 +1,9 @@
 package router
 
 import (
+	"net/http"
+	"net/url"
+
 	"github.com/labstack/echo/v4"
 	"github.com/shanto-323/backend-scaffold/internal/server"
 	"github.com/shanto-323/backend-scaffold/internal/server/handler"
@@ -10,20 +13,46 @@ import (
 
 const ApiVersion = "/api/v1"
 
-func NewRouter(s *server.Server, h *handler.Handlers) *echo.Echo {
+func NewRouter(s *server.Server, h *handler.Handlers, mockIdP http.Handler) *echo.Echo {
 	middlewares := middleware.New(s)
 
 	router := echo.New()
+	router.HTTPErrorHandler = handler.NewErrorHandler(s)
+	router.IPExtractor = middlewares.IPExtractor()
 
 	router.Use(
 		middleware.RequestID(),
-		middlewares.EnhanceContext(),
+		middlewares.Recover(),
+		middlewares.RecordMetrics(),
+		middleware.Localize(),
+		middlewares.CORS(),
+		middlewares.SecureHeaders(),
+		middlewares.BodyLimit(""),
+		middlewares.LoadSession(),
+		middlewares.ClientIdentity(),
 		middlewares.EnhanceTracing(),
+		middlewares.EnhanceContext(),
 	)
 
-	registerSystemRouter(router, h.HealthHandler)
+	registerSystemRouter(s, router, h, middlewares)
+	registerMockIdP(s, router, mockIdP)
 
-	r := router.Group(ApiVersion)
-	v1.RegisterV1Routes(r, h, middlewares)
+	v1.RegisterV1Routes(router.Group(ApiVersion), h, middlewares)
 	return router
 }
+
+// registerMockIdP serves the development identity provider under the path of
+// the configured issuer, so browsers can reach its authorize endpoint.
+func registerMockIdP(s *server.Server, r *echo.Echo, mockIdP http.Handler) {
+	if mockIdP == nil {
+		return
+	}
+
+	issuer, err := url.Parse(s.Config.OIDC.IssuerURL)
+	if err != nil || issuer.Path == "" {
+		s.Logger.Error().Str("issuer", s.Config.OIDC.IssuerURL).Msg("mock identity provider needs an issuer url with a path")
+		return
+	}
+
+	r.Any(issuer.Path+"/*", echo.WrapHandler(http.StripPrefix(issuer.Path, mockIdP)))
+}
@@ -2,9 +2,20 @@ package router
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/server"
 	"github.com/shanto-323/backend-scaffold/internal/server/handler"
+	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
 )
 
-func registerSystemRouter(r *echo.Echo, h *handler.HealthHandler) {
-	r.GET("/status", h.CheckHealth)
+func registerSystemRouter(s *server.Server, r *echo.Echo, h *handler.Handlers, m *middleware.Middlewares) {
+	r.GET("/status", h.HealthHandler.CheckHealth, m.Timeout(config.TimeoutPolicyRead))
+	r.GET("/livez", h.HealthHandler.Live)
+	r.GET("/readyz", h.HealthHandler.CheckHealth, m.Timeout(config.TimeoutPolicyRead))
+
+	r.GET(s.Config.Monitor.Metrics.Path, h.MetricsHandler.Scrape, m.IPAccess("metrics"))
+
+	problems := r.Group("/problems")
+	problems.GET("", h.ProblemHandler.ListProblemTypes)
+	problems.GET("/:slug", h.ProblemHandler.GetProblemType)
 }
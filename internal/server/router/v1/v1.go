@@ -2,12 +2,44 @@ package v1
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/shanto-323/backend-scaffold/config"
 	"github.com/shanto-323/backend-scaffold/internal/server/handler"
 	"github.com/shanto-323/backend-scaffold/internal/server/middleware"
 )
 
 func RegisterV1Routes(r *echo.Group, h *handler.Handlers, m *middleware.Middlewares) {
-	student := r.Group("/student")
+	// Both groups share one limiter. Tenant scoped routes resolve the tenant
+	// first so its rate limit applies.
+	rateLimit := m.RateLimitHit()
+	public := r.Group("", rateLimit, m.AllowContentTypes())
+	scoped := r.Group("", m.ResolveTenant(), rateLimit, m.AllowContentTypes())
 
-	student.POST("", h.StudentHandler.Create)
+	student := scoped.Group("/student", m.Timeout(config.TimeoutPolicyWrite))
+
+	student.POST("", h.StudentHandler.Create, m.BodyLimit("64K"), m.Idempotent())
+	student.GET("/search", h.StudentHandler.Search, m.RequireSession(), m.RequireStaff())
+
+	sessions := scoped.Group("/sessions", m.RequireSession(), m.Timeout(config.TimeoutPolicyRead))
+
+	sessions.GET("", h.SessionHandler.List)
+	sessions.DELETE("", h.SessionHandler.RevokeOthers)
+	sessions.DELETE("/:id", h.SessionHandler.Revoke)
+	sessions.POST("/logout", h.SessionHandler.Logout)
+
+	oidc := public.Group("/auth/oidc", m.Timeout(config.TimeoutPolicyDefault))
+
+	oidc.GET("/login", h.AuthHandler.Login)
+	oidc.GET("/callback", h.AuthHandler.Callback)
+
+	// Senders post their own content types from a few addresses, the API rate
+	// limit and content type allow list do not apply.
+	webhooks := r.Group("/webhooks", m.IPAccess("webhooks"), m.Timeout(config.TimeoutPolicyWrite))
+
+	webhooks.POST("/:source", h.WebhookHandler.Receive, m.VerifyWebhook())
+
+	admin := scoped.Group("/admin", m.IPAccess("admin"), m.RequireSession(), m.RequireAdmin(), m.Timeout(config.TimeoutPolicyDefault))
+
+	admin.GET("/log-levels", h.LogLevelHandler.List)
+	admin.PUT("/log-levels", h.LogLevelHandler.Update)
+	admin.POST("/webhooks/:id/reprocess", h.WebhookHandler.Reprocess)
 }
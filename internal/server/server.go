@@ -4,11 +4,15 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/internal/health"
 	"github.com/shanto-323/backend-scaffold/internal/repository"
+	logs "github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/metrics"
 	"github.com/shanto-323/backend-scaffold/pkg/tracer"
 )
 
@@ -17,7 +21,16 @@ type Server struct {
 	Logger        *zerolog.Logger
 	Repository    *repository.Repository
 	TraceProvider *tracer.TraceProvider
+	MeterProvider *tracer.MeterProvider
+	Metrics       *metrics.Metrics
+	Health        *health.Checker
 	httpServer    *http.Server
+	// shuttingDown is set by Stop, requests still running are told to retry.
+	shuttingDown atomic.Bool
+
+	// tlsWatch bounds the certificate reloader, cancelled by Stop.
+	tlsWatch     context.Context
+	stopTLSWatch context.CancelFunc
 }
 
 func NewServer(logger *zerolog.Logger, config *config.Config) (*Server, error) {
@@ -27,20 +40,54 @@ func NewServer(logger *zerolog.Logger, config *config.Config) (*Server, error) {
 		return nil, err
 	}
 
-	repository, err := repository.New(config, logger, tp.Tracer)
+	mp, err := tracer.NewMeterProvider(context.Background(), config)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := metrics.New(config)
+	if err != nil {
+		return nil, err
+	}
+
+	repository, err := repository.New(config, logger, tp.Tracer, m)
 	if err != nil {
 		return nil, err
 	}
+	if err := m.Register(
+		metrics.NewPgxPoolCollector(config.Monitor.Metrics.Namespace, repository.DatabaseDriver.PoolStats),
+		metrics.NewRedisPoolCollector(config.Monitor.Metrics.Namespace, repository.CacheProvider.PoolStats),
+		metrics.NewLogDropsCollector(config.Monitor.Metrics.Namespace, logs.DroppedLines),
+	); err != nil {
+		return nil, err
+	}
+
+	checkerLogger := logs.Component(logs.ComponentJobs)
+	checker := health.New(config.Monitor.HealthChecks, config.Primary.Env, &checkerLogger, healthChecks(repository))
 
+	tlsWatch, stopTLSWatch := context.WithCancel(context.Background())
 
 	return &Server{
 		Config:        config,
 		Logger:        logger,
 		Repository:    repository,
 		TraceProvider: tp,
+		MeterProvider: mp,
+		Metrics:       m,
+		Health:        checker,
+		tlsWatch:      tlsWatch,
+		stopTLSWatch:  stopTLSWatch,
 	}, nil
 }
 
+// healthChecks maps the check names of HealthChecksConfig to dependencies.
+func healthChecks(r *repository.Repository) map[string]health.CheckFunc {
+	return map[string]health.CheckFunc{
+		config.HealthCheckDatabase: r.DatabaseDriver.Ping,
+		config.HealthCheckRedis:    r.CacheProvider.Ping,
+	}
+}
+
 func (s *Server) SetUpHTTPServer(handler http.Handler) {
 	s.httpServer = &http.Server{
 		Addr:         ":" + s.Config.Server.Port,
@@ -56,16 +103,47 @@ func (s *Server) Run() error {
 		return errors.New("HTTP server not initialized")
 	}
 
+	tlsConfig := s.Config.Server.TLS
 	s.Logger.Info().
 		Str("port", s.Config.Server.Port).
 		Str("env", s.Config.Primary.Env).
+		Bool("tls", tlsConfig.Enabled).
+		Str("client_auth", tlsConfig.ClientAuth).
 		Msg("starting server")
-	return s.httpServer.ListenAndServe()
+
+	if !tlsConfig.Enabled {
+		return s.httpServer.ListenAndServe()
+	}
+
+	reloader, err := newCertReloader(&tlsConfig, s.Logger)
+	if err != nil {
+		return err
+	}
+	s.httpServer.TLSConfig, err = reloader.TLSConfig()
+	if err != nil {
+		return err
+	}
+
+	go reloader.Watch(s.tlsWatch)
+
+	// The certificate comes from the TLS config, not from files given here.
+	return s.httpServer.ListenAndServeTLS("", "")
+}
+
+// ShuttingDown reports whether Stop was called.
+func (s *Server) ShuttingDown() bool {
+	return s.shuttingDown.Load()
 }
 
 func (s *Server) Stop(ctx context.Context) error {
+	s.shuttingDown.Store(true)
+	s.stopTLSWatch()
+
 	if err := s.TraceProvider.Shutdown(ctx); err != nil {
 		return err
 	}
+	if err := s.MeterProvider.Shutdown(ctx); err != nil {
+		return err
+	}
 	return nil
 }
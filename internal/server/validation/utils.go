@@ -1,13 +1,13 @@
 package validation
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator"
 	"github.com/labstack/echo/v4"
 	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/server/i18n"
 )
 
 type Validatable interface {
@@ -49,7 +49,11 @@ func extrectValidationErrors(err error) (string, []errs.FieldError) {
 
 	validationErrors, ok := err.(validator.ValidationErrors)
 	if !ok {
-		customValidationError := err.(CustomValidationErrors)
+		customValidationError, ok := err.(CustomValidationErrors)
+		if !ok {
+			// Plain errors from Validate carry no field information.
+			return "Validation failed: " + err.Error(), nil
+		}
 		for _, err := range customValidationError {
 			fieldErrors = append(fieldErrors, errs.FieldError{
 				Field: err.Field,
@@ -60,46 +64,16 @@ func extrectValidationErrors(err error) (string, []errs.FieldError) {
 
 	for _, err := range validationErrors {
 		field := strings.ToLower(err.Field())
-		var msg string
-
-		switch err.Tag() {
-		case "required":
-			msg = "is required"
-		case "min":
-			if err.Type().Kind() == reflect.String {
-				msg = fmt.Sprintf("must be at least %s characters", err.Param())
-			} else {
-				msg = fmt.Sprintf("must be at least %s", err.Param())
-			}
-		case "max":
-			if err.Type().Kind() == reflect.String {
-				msg = fmt.Sprintf("must not exceed %s characters", err.Param())
-			} else {
-				msg = fmt.Sprintf("must not exceed %s", err.Param())
-			}
-		case "oneof":
-			msg = fmt.Sprintf("must be one of: %s", err.Param())
-		case "email":
-			msg = "must be a valid email address"
-		case "e164":
-			msg = "must be a valid phone number with country code"
-		case "uuid":
-			msg = "must be a valid UUID"
-		case "uuidList":
-			msg = "must be a comma-separated list of valid UUIDs"
-		case "dive":
-			msg = "some items are invalid"
-		default:
-			if err.Param() != "" {
-				msg = fmt.Sprintf("%s: %s:%s", field, err.Tag(), err.Param())
-			} else {
-				msg = fmt.Sprintf("%s: %s", field, err.Tag())
-			}
+		tag := err.Tag()
+		if (tag == "min" || tag == "max") && err.Type().Kind() == reflect.String {
+			tag += ".string"
 		}
 
 		fieldErrors = append(fieldErrors, errs.FieldError{
-			Field: strings.ToLower(err.Field()),
-			Error: msg,
+			Field: field,
+			Error: i18n.ValidationMessage(i18n.Fallback, tag, field, err.Param()),
+			Tag:   tag,
+			Param: err.Param(),
 		})
 	}
 
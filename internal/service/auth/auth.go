@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shanto-323/backend-scaffold/internal/repository/cache"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/internal/service/session"
+	"github.com/shanto-323/backend-scaffold/internal/tenant"
+	"github.com/shanto-323/backend-scaffold/model"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/oidc"
+	"github.com/shanto-323/backend-scaffold/pkg/oidc/mockidp"
+	"github.com/shanto-323/backend-scaffold/pkg/tracer"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	loginStateKeyPrefix = "oidc_login:"
+
+	// LoginStateTTL is how long a started login can be completed.
+	LoginStateTTL = 10 * time.Minute
+)
+
+// loginState is kept between the redirect to the provider and the callback.
+type loginState struct {
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+}
+
+type auth struct {
+	s        *server.Server
+	sessions session.Service
+	provider *oidc.Provider
+	mock     *mockidp.IdP
+}
+
+func NewService(s *server.Server, sessions session.Service) (Service, error) {
+	cfg := s.Config.OIDC
+	a := &auth{
+		s:        s,
+		sessions: sessions,
+	}
+
+	if !cfg.Enabled {
+		return a, nil
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	if cfg.MockIdP {
+		mock, err := mockidp.New(cfg.IssuerURL)
+		if err != nil {
+			return nil, err
+		}
+		a.mock = mock
+		httpClient.Transport = mock.Transport(nil)
+		s.Logger.Warn().Str("issuer", cfg.IssuerURL).Msg("mock identity provider enabled, every login is approved")
+	}
+	httpClient.Transport = tracer.Transport(s.TraceProvider.Tracer, httpClient.Transport)
+
+	a.provider = oidc.New(oidc.Config{
+		IssuerURL:    cfg.IssuerURL,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+		HTTPClient:   httpClient,
+	})
+
+	return a, nil
+}
+
+func (a *auth) MockIdP() http.Handler {
+	if a.mock == nil {
+		return nil
+	}
+	return a.mock
+}
+
+func (a *auth) LoginURL(ctx context.Context) (string, string, error) {
+	ctx, span := a.s.TraceProvider.Start(ctx, "auth.login")
+	defer span.End()
+
+	if a.provider == nil {
+		return "", "", errs.NewNotFoundError("OIDC login is not enabled", false, nil)
+	}
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		return "", "", err
+	}
+
+	data, err := json.Marshal(loginState{
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to encode login state: %w", err)
+	}
+
+	if err := a.s.Repository.CacheProvider.Set(ctx, loginStateKeyPrefix+state, data, LoginStateTTL); err != nil {
+		return "", "", fmt.Errorf("failed to store login state: %w", err)
+	}
+
+	authURL, err := a.provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		span.RecordError(err)
+		return "", "", errs.NewServiceUnavailableError("Identity provider unavailable")
+	}
+	return authURL, state, nil
+}
+
+func (a *auth) Callback(ctx context.Context, params CallbackParams) (*model.Session, error) {
+	ctx, span := a.s.TraceProvider.Start(ctx, "auth.callback")
+	defer span.End()
+
+	if a.provider == nil {
+		return nil, errs.NewNotFoundError("OIDC login is not enabled", false, nil)
+	}
+
+	state, err := a.takeLoginState(ctx, params.State)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := a.provider.Exchange(ctx, params.Code, state.CodeVerifier)
+	if err != nil {
+		span.RecordError(err)
+		logger.FromContext(ctx, a.s.Logger).Warn().Err(err).Msg("oidc code exchange failed")
+		return nil, errs.NewUnauthorizedError("Login failed", false)
+	}
+
+	claims, err := a.provider.Verify(ctx, token.IDToken, state.Nonce)
+	if err != nil {
+		span.RecordError(err)
+		logger.FromContext(ctx, a.s.Logger).Warn().Err(err).Msg("oidc id token rejected")
+		return nil, errs.NewUnauthorizedError("Login failed", false)
+	}
+
+	cfg := a.s.Config.OIDC
+	userID := stringClaim(claims, cfg.UserIDClaim)
+	if userID == "" {
+		return nil, errs.NewUnauthorizedError("Login failed", false)
+	}
+
+	role := stringClaim(claims, cfg.RoleClaim)
+	if role == "" {
+		role = cfg.DefaultRole
+	}
+
+	// The session is bound to the tenant of the identity provider, never to
+	// one the browser asked for.
+	tenantID := stringClaim(claims, cfg.TenantClaim)
+	if tenantID == "" && a.s.Config.Tenancy.Enabled {
+		logger.FromContext(ctx, a.s.Logger).Warn().Str("claim", cfg.TenantClaim).Msg("oidc id token has no tenant")
+		return nil, errs.NewUnauthorizedError("Login failed", false)
+	}
+	if tenantID != "" && !tenant.Valid(tenantID) {
+		return nil, errs.NewUnauthorizedError("Login failed", false)
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("user_role", role),
+	)
+
+	return a.sessions.Create(ctx, session.CreateParams{
+		UserID:    userID,
+		UserRole:  role,
+		TenantID:  tenantID,
+		IP:        params.IP,
+		UserAgent: params.UserAgent,
+	})
+}
+
+// takeLoginState loads and deletes the state so a callback can not be replayed.
+func (a *auth) takeLoginState(ctx context.Context, state string) (*loginState, error) {
+	if state == "" {
+		return nil, errs.NewBadRequestError("Missing login state", false, nil, nil, nil)
+	}
+
+	key := loginStateKeyPrefix + state
+	data, err := a.s.Repository.CacheProvider.Get(ctx, key)
+	if errors.Is(err, cache.ErrNotFound) {
+		return nil, errs.NewBadRequestError("Login expired or already completed", false, nil, nil, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load login state: %w", err)
+	}
+
+	if err := a.s.Repository.CacheProvider.Delete(ctx, key); err != nil {
+		return nil, fmt.Errorf("failed to delete login state: %w", err)
+	}
+
+	loaded := &loginState{}
+	if err := json.Unmarshal(data, loaded); err != nil {
+		return nil, fmt.Errorf("failed to decode login state: %w", err)
+	}
+	return loaded, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
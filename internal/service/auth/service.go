@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Service interface {
+	// LoginURL starts an OIDC login and returns the authorization URL and the
+	// state the browser has to bring back to the callback.
+	LoginURL(ctx context.Context) (string, string, error)
+	// Callback completes the login and opens a session for the user.
+	Callback(ctx context.Context, params CallbackParams) (*model.Session, error)
+	// MockIdP returns the in-process identity provider, nil unless enabled.
+	MockIdP() http.Handler
+}
+
+type CallbackParams struct {
+	Code      string
+	State     string
+	IP        string
+	UserAgent string
+}
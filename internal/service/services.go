@@ -2,15 +2,31 @@ package service
 
 import (
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/service/auth"
+	"github.com/shanto-323/backend-scaffold/internal/service/session"
 	"github.com/shanto-323/backend-scaffold/internal/service/student"
+	"github.com/shanto-323/backend-scaffold/internal/service/webhook"
 )
 
 type Services struct {
 	StudentService student.Service
+	SessionService session.Service
+	AuthService    auth.Service
+	WebhookService webhook.Service
 }
 
-func New(s *server.Server) *Services {
+func New(s *server.Server) (*Services, error) {
+	sessionService := session.NewService(s)
+
+	authService, err := auth.NewService(s, sessionService)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Services{
 		StudentService: student.NewService(s),
-	}
+		SessionService: sessionService,
+		AuthService:    authService,
+		WebhookService: webhook.NewService(s),
+	}, nil
 }
@@ -0,0 +1,22 @@
+package session
+
+import (
+	"context"
+
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type Service interface {
+	Create(ctx context.Context, params CreateParams) (*model.Session, error)
+	List(ctx context.Context, userID string) ([]*model.Session, error)
+	Revoke(ctx context.Context, userID, sessionID string) error
+	RevokeAll(ctx context.Context, userID, exceptSessionID string) error
+}
+
+type CreateParams struct {
+	UserID    string
+	UserRole  string
+	TenantID  string
+	IP        string
+	UserAgent string
+}
@@ -0,0 +1,115 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/server/errs"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+type session struct {
+	s *server.Server
+}
+
+func NewService(s *server.Server) Service {
+	return &session{
+		s: s,
+	}
+}
+
+func (se *session) Create(ctx context.Context, params CreateParams) (*model.Session, error) {
+	ctx, span := se.s.TraceProvider.Start(ctx, "session.create")
+	defer span.End()
+
+	id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	csrfToken, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := se.s.Config.Session
+	now := time.Now().UTC()
+	sess := &model.Session{
+		ID:         id,
+		UserID:     params.UserID,
+		UserRole:   params.UserRole,
+		TenantID:   params.TenantID,
+		CSRFToken:  csrfToken,
+		IP:         params.IP,
+		UserAgent:  params.UserAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(cfg.AbsoluteTimeout),
+	}
+
+	if err := se.s.Repository.SessionStore.Save(ctx, sess, cfg.IdleTimeout); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+func (se *session) List(ctx context.Context, userID string) ([]*model.Session, error) {
+	ctx, span := se.s.TraceProvider.Start(ctx, "session.list")
+	defer span.End()
+
+	return se.s.Repository.SessionStore.List(ctx, userID)
+}
+
+// Revoke ends one session of the user. Sessions of other users are reported
+// as not found so their ids can not be probed.
+func (se *session) Revoke(ctx context.Context, userID, sessionID string) error {
+	ctx, span := se.s.TraceProvider.Start(ctx, "session.revoke")
+	defer span.End()
+
+	sessions, err := se.s.Repository.SessionStore.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, sess := range sessions {
+		if sess.ID == sessionID {
+			return se.s.Repository.SessionStore.Delete(ctx, userID, sessionID)
+		}
+	}
+
+	return errs.NewNotFoundError("Session not found", false, nil)
+}
+
+// RevokeAll ends every session of the user except exceptSessionID, which is
+// usually the session making the request.
+func (se *session) RevokeAll(ctx context.Context, userID, exceptSessionID string) error {
+	ctx, span := se.s.TraceProvider.Start(ctx, "session.revoke_all")
+	defer span.End()
+
+	sessions, err := se.s.Repository.SessionStore.List(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		if sess.ID != exceptSessionID {
+			ids = append(ids, sess.ID)
+		}
+	}
+
+	return se.s.Repository.SessionStore.Delete(ctx, userID, ids...)
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
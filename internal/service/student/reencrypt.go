@@ -0,0 +1,64 @@
+package student
+
+import (
+	"context"
+	"time"
+
+	logs "github.com/shanto-323/backend-scaffold/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (st *student) RunReencryption(ctx context.Context) {
+	cfg := st.s.Config.Encryption
+	if cfg.ReencryptInterval <= 0 {
+		return
+	}
+
+	ctx = logs.Component(logs.ComponentJobs).WithContext(ctx)
+
+	ticker := time.NewTicker(cfg.ReencryptInterval)
+	defer ticker.Stop()
+
+	for {
+		st.reencrypt(ctx, cfg.ReencryptBatchSize)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reencrypt works in batches until no row is left on an old key, so a single
+// transaction never holds many rows.
+func (st *student) reencrypt(ctx context.Context, batchSize int) {
+	ctx, span := st.s.TraceProvider.Start(ctx, "student.reencrypt")
+	defer span.End()
+
+	logger := logs.FromContext(ctx, st.s.Logger)
+
+	total := 0
+	defer func() {
+		span.SetAttributes(attribute.Int("students.reencrypted", total))
+		if total > 0 {
+			logger.Info().Int("students", total).Msg("re-encrypted students with the active key")
+		}
+	}()
+
+	for ctx.Err() == nil {
+		count, err := st.s.Repository.DatabaseDriver.ReencryptStudents(ctx, batchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				span.RecordError(err)
+				logger.Error().Err(err).Msg("failed to re-encrypt students")
+			}
+			return
+		}
+
+		total += count
+		if count < batchSize {
+			return
+		}
+	}
+}
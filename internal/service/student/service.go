@@ -8,4 +8,8 @@ import (
 
 type Service interface {
 	Create(ctx context.Context, payload *model.Student) (*model.Student, error)
+	Search(ctx context.Context, filter *model.SearchStudentsRequest) ([]*model.Student, error)
+	// RunReencryption moves rows sealed with retired keys to the active key
+	// until ctx is done.
+	RunReencryption(ctx context.Context)
 }
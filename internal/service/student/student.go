@@ -2,26 +2,41 @@ package student
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/internal/tenant"
 	"github.com/shanto-323/backend-scaffold/model"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
 type student struct {
-	s *server.Server
+	s       *server.Server
+	created metric.Int64Counter
 }
 
 func NewService(s *server.Server) Service {
+	// A failure here only means the counter is not exported.
+	created, err := s.MeterProvider.Meter.Int64Counter(
+		"students.created",
+		metric.WithDescription("Number of students created, by tenant"),
+		metric.WithUnit("{student}"),
+	)
+	if err != nil {
+		s.Logger.Error().Err(err).Msg("failed to create students counter")
+	}
+
 	return &student{
-		s: s,
+		s:       s,
+		created: created,
 	}
 }
 
 func (st *student) Create(ctx context.Context, payload *model.Student) (*model.Student, error) {
-	_, span := st.s.TraceProvider.Tracer.Start(ctx, "service")
+	ctx, span := st.s.TraceProvider.Start(ctx, "service")
 	defer span.End()
 
 	start := time.Now()
@@ -33,11 +48,43 @@ func (st *student) Create(ctx context.Context, payload *model.Student) (*model.S
 
 	}()
 
-	time.Sleep(2 * time.Second)
+	student := &model.Student{
+		ID:              uuid.New(),
+		Name:            payload.Name,
+		Roll:            payload.Roll,
+		Phone:           payload.Phone,
+		Address:         payload.Address,
+		GuardianContact: payload.GuardianContact,
+	}
+
+	if err := st.s.Repository.DatabaseDriver.CreateStudent(ctx, student); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			span.RecordError(err)
+		}
+		return nil, err
+	}
+
+	if st.created != nil {
+		st.created.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("tenant_id", tenant.FromContext(ctx)),
+		))
+	}
+
+	return student, nil
+}
+
+func (st *student) Search(ctx context.Context, filter *model.SearchStudentsRequest) ([]*model.Student, error) {
+	ctx, span := st.s.TraceProvider.Start(ctx, "student.search")
+	defer span.End()
+
+	students, err := st.s.Repository.DatabaseDriver.SearchStudents(ctx, filter)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			span.RecordError(err)
+		}
+		return nil, err
+	}
 
-	return &model.Student{
-		ID:   uuid.New(),
-		Name: payload.Name,
-		Roll: payload.Roll,
-	}, nil
+	span.SetAttributes(attribute.Int("students.count", len(students)))
+	return students, nil
 }
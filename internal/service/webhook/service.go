@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/shanto-323/backend-scaffold/model"
+)
+
+// Handler processes a verified event. Returning an error marks the event
+// failed, its payload stays stored for Reprocess.
+type Handler func(ctx context.Context, event *model.WebhookEvent) error
+
+type Service interface {
+	// Register routes events of source with eventType to handler, "*"
+	// matches every event type of the source.
+	Register(source, eventType string, handler Handler)
+	// Receive stores a verified delivery and dispatches it.
+	Receive(ctx context.Context, params ReceiveParams) (*model.WebhookEvent, error)
+	// Reprocess dispatches a stored event again.
+	Reprocess(ctx context.Context, id uuid.UUID) (*model.WebhookEvent, error)
+}
+
+type ReceiveParams struct {
+	Source      string
+	DeliveryID  string
+	EventType   string
+	ContentType string
+	Payload     []byte
+}
@@ -0,0 +1,171 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shanto-323/backend-scaffold/internal/server"
+	"github.com/shanto-323/backend-scaffold/model"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/tracer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const AnyEventType = "*"
+
+type webhook struct {
+	s *server.Server
+
+	mu       sync.RWMutex
+	handlers map[string]map[string]Handler
+}
+
+func NewService(s *server.Server) Service {
+	return &webhook{
+		s:        s,
+		handlers: map[string]map[string]Handler{},
+	}
+}
+
+func (w *webhook) Register(source, eventType string, handler Handler) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.handlers[source] == nil {
+		w.handlers[source] = map[string]Handler{}
+	}
+	w.handlers[source][eventType] = handler
+}
+
+func (w *webhook) Receive(ctx context.Context, params ReceiveParams) (*model.WebhookEvent, error) {
+	ctx, span := w.s.TraceProvider.Start(ctx, "webhook.receive")
+	defer span.End()
+
+	eventType := params.EventType
+	if eventType == "" {
+		eventType = payloadEventType(params.Payload)
+	}
+
+	event := &model.WebhookEvent{
+		ID:          uuid.New(),
+		Source:      params.Source,
+		EventType:   eventType,
+		DeliveryID:  params.DeliveryID,
+		ContentType: params.ContentType,
+		Payload:     params.Payload,
+		Status:      model.WebhookStatusReceived,
+		// Reprocessing runs outside this request, it links back to it.
+		TraceContext: tracer.Inject(ctx),
+	}
+
+	span.SetAttributes(
+		attribute.String("webhook.source", event.Source),
+		attribute.String("webhook.event_type", event.EventType),
+		attribute.String("webhook.id", event.ID.String()),
+	)
+
+	// Stored before dispatch, a crash in a handler never loses the delivery.
+	if err := w.s.Repository.DatabaseDriver.CreateWebhookEvent(ctx, event); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+
+	if err := w.dispatch(ctx, event); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return event, nil
+}
+
+func (w *webhook) Reprocess(ctx context.Context, id uuid.UUID) (*model.WebhookEvent, error) {
+	ctx, span := w.s.TraceProvider.Start(ctx, "webhook.reprocess")
+	defer span.End()
+
+	event, err := w.s.Repository.DatabaseDriver.GetWebhookEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	span.AddLink(trace.LinkFromContext(tracer.Extract(context.Background(), event.TraceContext)))
+
+	span.SetAttributes(
+		attribute.String("webhook.source", event.Source),
+		attribute.String("webhook.event_type", event.EventType),
+		attribute.String("webhook.id", event.ID.String()),
+	)
+
+	if err := w.dispatch(ctx, event); err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	return event, nil
+}
+
+// dispatch runs the handler and records the outcome. A failing handler is
+// not an error for the sender, the event is stored and can be reprocessed.
+func (w *webhook) dispatch(ctx context.Context, event *model.WebhookEvent) error {
+	logger := logger.FromContext(ctx, w.s.Logger).With().
+		Str("webhook_source", event.Source).
+		Str("webhook_event_type", event.EventType).
+		Str("webhook_id", event.ID.String()).
+		Logger()
+
+	event.Error = ""
+	handler := w.handler(event.Source, event.EventType)
+	switch {
+	case handler == nil:
+		event.Status = model.WebhookStatusUnhandled
+		logger.Warn().Msg("no handler registered for webhook event")
+	default:
+		if err := handler(ctx, event); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			event.Status = model.WebhookStatusFailed
+			event.Error = err.Error()
+			logger.Error().Err(err).Msg("webhook handler failed")
+		} else {
+			event.Status = model.WebhookStatusProcessed
+		}
+	}
+
+	if err := w.s.Repository.DatabaseDriver.UpdateWebhookEventStatus(context.WithoutCancel(ctx), event); err != nil {
+		return fmt.Errorf("failed to record webhook outcome: %w", err)
+	}
+	return nil
+}
+
+func (w *webhook) handler(source, eventType string) Handler {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if handler, ok := w.handlers[source][eventType]; ok {
+		return handler
+	}
+	return w.handlers[source][AnyEventType]
+}
+
+// payloadEventType reads the event type from JSON bodies of senders that do
+// not send it in a header.
+func payloadEventType(payload []byte) string {
+	var body struct {
+		Type  string `json:"type"`
+		Event string `json:"event"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return "unknown"
+	}
+
+	switch {
+	case body.Type != "":
+		return body.Type
+	case body.Event != "":
+		return body.Event
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,29 @@
+// Package tenant carries the resolved tenant (school) through request
+// contexts so every layer, including the database driver, can scope its work.
+package tenant
+
+import (
+	"context"
+	"regexp"
+)
+
+type contextKey struct{}
+
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Valid reports whether id is a well formed tenant identifier.
+func Valid(id string) bool {
+	return idPattern.MatchString(id)
+}
+
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant of the request or an empty string.
+func FromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(contextKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,18 @@
+package model
+
+type OIDCLoginRequest struct{}
+
+func (r *OIDCLoginRequest) Validate() error {
+	return nil
+}
+
+type OIDCCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+func (r *OIDCCallbackRequest) Validate() error {
+	return nil
+}
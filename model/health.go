@@ -10,8 +10,10 @@ type Report struct {
 }
 
 type Check struct {
-	Name         string `json:"name"`
-	Status       string `json:"status"`
-	ResponseTime string `json:"response_time"`
-	Error        string `json:"error,omitempty"`
+	Name                string    `json:"name"`
+	Status              string    `json:"status"`
+	ResponseTime        string    `json:"response_time"`
+	Error               string    `json:"error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures,omitempty"`
+	CheckedAt           time.Time `json:"checked_at"`
 }
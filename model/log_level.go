@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxLogLevelRevertAfter bounds temporary level changes.
+const MaxLogLevelRevertAfter = 24 * time.Hour
+
+// LogLevels are the effective levels by component, "global" included.
+type LogLevels struct {
+	Levels map[string]string `json:"levels"`
+}
+
+type GetLogLevelsRequest struct{}
+
+func (r *GetLogLevelsRequest) Validate() error {
+	return nil
+}
+
+// UpdateLogLevelRequest sets the level of one component, "global" by
+// default. Components accept "inherit" to follow the global level again.
+// RevertAfter is a duration such as "15m", the previous level comes back
+// once it passed.
+type UpdateLogLevelRequest struct {
+	Component   string `json:"component"`
+	Level       string `json:"level"`
+	RevertAfter string `json:"revert_after"`
+
+	revertAfter time.Duration
+}
+
+func (r *UpdateLogLevelRequest) Validate() error {
+	if r.Level == "" {
+		return fmt.Errorf("level is required")
+	}
+
+	if r.RevertAfter != "" {
+		d, err := time.ParseDuration(r.RevertAfter)
+		if err != nil || d <= 0 || d > MaxLogLevelRevertAfter {
+			return fmt.Errorf("revert_after must be a duration between 1s and %s", MaxLogLevelRevertAfter)
+		}
+		r.revertAfter = d
+	}
+
+	return nil
+}
+
+// RevertDuration is RevertAfter parsed by Validate, zero keeps the level.
+func (r *UpdateLogLevelRequest) RevertDuration() time.Duration {
+	return r.revertAfter
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	UserRole   string    `json:"user_role"`
+	TenantID   string    `json:"tenant_id,omitempty"`
+	CSRFToken  string    `json:"csrf_token"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// SessionInfo is the client facing view of a session, without its secrets.
+type SessionInfo struct {
+	ID         string    `json:"id"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+func (s *Session) Info(currentID string) SessionInfo {
+	return SessionInfo{
+		ID:         s.ID,
+		IP:         s.IP,
+		UserAgent:  s.UserAgent,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		Current:    s.ID == currentID,
+	}
+}
+
+type ListSessionsRequest struct{}
+
+func (r *ListSessionsRequest) Validate() error {
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	ID string `param:"id"`
+}
+
+func (r *RevokeSessionRequest) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("missing session id")
+	}
+	return nil
+}
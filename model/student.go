@@ -6,10 +6,15 @@ import (
 	"github.com/google/uuid"
 )
 
+// Student contact details are personal data, the repository stores them
+// encrypted and only phone and guardian contact can be searched.
 type Student struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
-	Roll int       `json:"roll"`
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Roll            int       `json:"roll"`
+	Phone           string    `json:"phone,omitempty"`
+	Address         string    `json:"address,omitempty"`
+	GuardianContact string    `json:"guardian_contact,omitempty"`
 }
 
 func (s *Student) Validate() error{
@@ -19,3 +24,17 @@ func (s *Student) Validate() error{
 
 	return  nil
 }
+
+// SearchStudentsRequest matches students by exact phone or guardian contact.
+type SearchStudentsRequest struct {
+	Phone           string `query:"phone"`
+	GuardianContact string `query:"guardian_contact"`
+}
+
+func (r *SearchStudentsRequest) Validate() error {
+	if r.Phone == "" && r.GuardianContact == "" {
+		return fmt.Errorf("phone or guardian_contact is required")
+	}
+
+	return nil
+}
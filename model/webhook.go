@@ -0,0 +1,59 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookStatusReceived  = "received"
+	WebhookStatusProcessed = "processed"
+	WebhookStatusFailed    = "failed"
+	// WebhookStatusUnhandled means no handler was registered for the event,
+	// it can be reprocessed once one is.
+	WebhookStatusUnhandled = "unhandled"
+)
+
+// WebhookEvent is a verified delivery, stored with its raw payload so it can
+// be reprocessed.
+type WebhookEvent struct {
+	ID          uuid.UUID `json:"id"`
+	Source      string    `json:"source"`
+	EventType   string    `json:"event_type"`
+	DeliveryID  string    `json:"delivery_id"`
+	ContentType string    `json:"-"`
+	Payload     []byte    `json:"-"`
+	// TraceContext is the W3C trace context of the delivering request.
+	TraceContext map[string]string `json:"-"`
+	Status       string            `json:"status"`
+	Error        string            `json:"error,omitempty"`
+	Attempts     int               `json:"attempts"`
+	ReceivedAt   time.Time         `json:"received_at"`
+	ProcessedAt  *time.Time        `json:"processed_at,omitempty"`
+}
+
+type ReceiveWebhookRequest struct {
+	Source string `param:"source"`
+}
+
+func (r *ReceiveWebhookRequest) Validate() error {
+	return nil
+}
+
+type ReprocessWebhookRequest struct {
+	ID uuid.UUID `param:"id"`
+}
+
+func (r *ReprocessWebhookRequest) Validate() error {
+	if r.ID == uuid.Nil {
+		return fmt.Errorf("missing webhook event id")
+	}
+	return nil
+}
+
+type WebhookReceipt struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
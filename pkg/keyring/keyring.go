@@ -0,0 +1,161 @@
+// Package keyring encrypts individual fields with AES-GCM. Every ciphertext
+// is tied to the id of the key that sealed it so keys can rotate while old
+// rows stay readable, and blind indexes allow equality search without
+// decrypting.
+package keyring
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shanto-323/backend-scaffold/config"
+)
+
+const (
+	keySize = 32
+
+	// DerivedKeyID names the key derived from the secret key. It is active
+	// when no keys are configured and always kept to decrypt rows written
+	// before keys were.
+	DerivedKeyID = "derived-v1"
+)
+
+var (
+	ErrUnknownKey = errors.New("keyring: unknown key id")
+	ErrDecrypt    = errors.New("keyring: decryption failed")
+)
+
+type Keyring struct {
+	active   string
+	aeads    map[string]cipher.AEAD
+	indexKey []byte
+}
+
+func New(cfg config.EncryptionConfig, secretKey string) (*Keyring, error) {
+	keys := map[string][]byte{}
+	active := cfg.ActiveKeyID
+
+	for _, entry := range cfg.Keys {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("keyring: key entries must look like id:base64-key")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != keySize {
+			return nil, fmt.Errorf("keyring: key %s must be %d base64 encoded bytes", id, keySize)
+		}
+		keys[id] = key
+	}
+
+	if len(keys) == 0 {
+		active = DerivedKeyID
+	}
+	if _, ok := keys[DerivedKeyID]; !ok {
+		key, err := derive(secretKey, "field-encryption")
+		if err != nil {
+			return nil, err
+		}
+		keys[DerivedKeyID] = key
+	}
+
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("keyring: active key %q is not in the keyring", active)
+	}
+
+	indexKey, err := derive(secretKey, "blind-index")
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BlindIndexKey != "" {
+		indexKey, err = base64.StdEncoding.DecodeString(cfg.BlindIndexKey)
+		if err != nil || len(indexKey) < keySize {
+			return nil, fmt.Errorf("keyring: blind index key must be at least %d base64 encoded bytes", keySize)
+		}
+	}
+
+	k := &Keyring{
+		active:   active,
+		aeads:    make(map[string]cipher.AEAD, len(keys)),
+		indexKey: indexKey,
+	}
+	for id, key := range keys {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %s: %w", id, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("keyring: key %s: %w", id, err)
+		}
+		k.aeads[id] = aead
+	}
+
+	return k, nil
+}
+
+// ActiveKeyID is the key new values are encrypted with.
+func (k *Keyring) ActiveKeyID() string {
+	return k.active
+}
+
+// Encrypt seals plaintext with the active key. The additional data, usually
+// the row id and column, has to match on decryption, so a ciphertext copied
+// into another row or column does not decrypt.
+func (k *Keyring) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	aead := k.aeads[k.active]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("keyring: failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (k *Keyring) Decrypt(keyID string, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// BlindIndex returns a keyed hash of the normalized value. The field name is
+// part of the hash so equal values in different fields do not match.
+func (k *Keyring) BlindIndex(field, value string) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(Normalize(value)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Normalize is applied before indexing so equality search ignores case,
+// surrounding and inner whitespace.
+func Normalize(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), ""))
+}
+
+func derive(secret, purpose string) ([]byte, error) {
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, "backend-scaffold "+purpose, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("keyring: failed to derive %s key: %w", purpose, err)
+	}
+	return key, nil
+}
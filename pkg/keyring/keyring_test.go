@@ -0,0 +1,46 @@
+package keyring_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/pkg/keyring"
+)
+
+const secretKey = "test-secret-key"
+
+func TestDerivedKeyStaysReadable(t *testing.T) {
+	before, err := keyring.New(config.EncryptionConfig{}, secretKey)
+	if err != nil {
+		t.Fatalf("keyring.New: %v", err)
+	}
+	if before.ActiveKeyID() != keyring.DerivedKeyID {
+		t.Fatalf("active key = %s, want %s", before.ActiveKeyID(), keyring.DerivedKeyID)
+	}
+
+	sealed, err := before.Encrypt([]byte("01712345678"), []byte("row:phone"))
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	after, err := keyring.New(config.EncryptionConfig{
+		Keys:        []string{"v2:" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))},
+		ActiveKeyID: "v2",
+	}, secretKey)
+	if err != nil {
+		t.Fatalf("keyring.New: %v", err)
+	}
+	if after.ActiveKeyID() != "v2" {
+		t.Fatalf("active key = %s, want v2", after.ActiveKeyID())
+	}
+
+	plaintext, err := after.Decrypt(keyring.DerivedKeyID, sealed, []byte("row:phone"))
+	if err != nil {
+		t.Fatalf("Decrypt with the derived key: %v", err)
+	}
+	if string(plaintext) != "01712345678" {
+		t.Errorf("Decrypt = %q, want the original value", plaintext)
+	}
+}
@@ -0,0 +1,358 @@
+package logger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Components have their own level, changeable at runtime. A component without
+// a level of its own follows the global one.
+const (
+	ComponentGlobal   = "global"
+	ComponentHTTP     = "http"
+	ComponentDatabase = "database"
+	ComponentCache    = "cache"
+	ComponentJobs     = "jobs"
+
+	// LevelInherit clears the level of a component.
+	LevelInherit = "inherit"
+)
+
+var Components = []string{ComponentHTTP, ComponentDatabase, ComponentCache, ComponentJobs}
+
+var ErrUnknownComponent = errors.New("unknown log component")
+
+// Levels are process wide like zerolog's global level. Loggers are built at
+// trace level and filtered by levelHook, zerolog's global level is kept at
+// the lowest effective level so skipped lines cost nothing.
+var levels = newLevelRegistry()
+
+type levelRegistry struct {
+	mu sync.Mutex
+	// root has no level hook, audit lines are written through it.
+	root zerolog.Logger
+	// configured is restored on SIGUSR1 before the levels file is applied.
+	configured map[string]zerolog.Level
+	// set holds the global level and the components with a level of their
+	// own.
+	set     map[string]zerolog.Level
+	reverts map[string]*time.Timer
+
+	// effective is read by levelHook on every line.
+	effective map[string]*atomic.Int32
+}
+
+func newLevelRegistry() *levelRegistry {
+	r := &levelRegistry{
+		root:       zerolog.Nop(),
+		configured: map[string]zerolog.Level{ComponentGlobal: zerolog.InfoLevel},
+		set:        map[string]zerolog.Level{ComponentGlobal: zerolog.InfoLevel},
+		reverts:    map[string]*time.Timer{},
+		effective:  map[string]*atomic.Int32{},
+	}
+	for _, name := range append([]string{ComponentGlobal}, Components...) {
+		r.effective[name] = &atomic.Int32{}
+	}
+	r.apply()
+	return r
+}
+
+// configure installs root and the configured levels, called once by
+// NewLoggerWithService.
+func (r *levelRegistry) configure(root zerolog.Logger, global zerolog.Level, components map[string]zerolog.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.root = root
+	r.configured = map[string]zerolog.Level{ComponentGlobal: global}
+	for name, level := range components {
+		r.configured[name] = level
+	}
+	r.set = copyLevels(r.configured)
+	r.apply()
+}
+
+// apply publishes the effective levels, r.mu must be held.
+func (r *levelRegistry) apply() {
+	global := r.set[ComponentGlobal]
+	lowest := global
+	for name, effective := range r.effective {
+		level, ok := r.set[name]
+		if !ok {
+			level = global
+		}
+		effective.Store(int32(level))
+		if level < lowest {
+			lowest = level
+		}
+	}
+	zerolog.SetGlobalLevel(lowest)
+}
+
+func (r *levelRegistry) level(component string) zerolog.Level {
+	effective, ok := r.effective[component]
+	if !ok {
+		effective = r.effective[ComponentGlobal]
+	}
+	return zerolog.Level(effective.Load())
+}
+
+// Change describes a runtime level change for the audit log.
+type Change struct {
+	Component string
+	// Level is a zerolog level name, or LevelInherit for components.
+	Level string
+	// RevertAfter restores the previous level after the duration, zero keeps
+	// the new one.
+	RevertAfter time.Duration
+	// Actor and Source say who asked and how, e.g. a user id and "api".
+	Actor  string
+	Source string
+}
+
+// SetLevel changes the level of a component or the global level.
+func SetLevel(change Change) error {
+	return levels.change(change)
+}
+
+func (r *levelRegistry) change(change Change) error {
+	if _, ok := r.effective[change.Component]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownComponent, change.Component)
+	}
+	if change.Level == LevelInherit && change.Component == ComponentGlobal {
+		return fmt.Errorf("the global level can not inherit")
+	}
+	if change.Level != LevelInherit {
+		if _, err := ParseLevel(change.Level); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.update(change)
+	return nil
+}
+
+// update applies a validated change, r.mu must be held.
+func (r *levelRegistry) update(change Change) {
+	previous, hadLevel := r.set[change.Component]
+	if change.Level == LevelInherit {
+		delete(r.set, change.Component)
+	} else {
+		level, _ := ParseLevel(change.Level)
+		r.set[change.Component] = level
+	}
+	r.apply()
+
+	// A newer change replaces a pending revert of the same component.
+	if timer, ok := r.reverts[change.Component]; ok {
+		timer.Stop()
+		delete(r.reverts, change.Component)
+	}
+	if change.RevertAfter > 0 {
+		revert := Change{
+			Component: change.Component,
+			Level:     LevelInherit,
+			Actor:     change.Actor,
+			Source:    "revert",
+		}
+		if hadLevel {
+			revert.Level = previous.String()
+		}
+
+		var timer *time.Timer
+		timer = time.AfterFunc(change.RevertAfter, func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			if r.reverts[change.Component] == timer {
+				r.update(revert)
+			}
+		})
+		r.reverts[change.Component] = timer
+	}
+
+	from := LevelInherit
+	if hadLevel {
+		from = previous.String()
+	}
+	event := r.root.Log().
+		Str("audit", "log_level").
+		Str("component", change.Component).
+		Str("from", from).
+		Str("to", change.Level).
+		Str("actor", change.Actor).
+		Str("source", change.Source)
+	if change.RevertAfter > 0 {
+		event = event.Dur("revert_after", change.RevertAfter)
+	}
+	event.Msg("log level changed")
+}
+
+// CurrentLevels returns the effective level of the global logger and of
+// every component.
+func CurrentLevels() map[string]string {
+	result := make(map[string]string, len(levels.effective))
+	for name := range levels.effective {
+		result[name] = levels.level(name).String()
+	}
+	return result
+}
+
+// Component returns the logger of a component, filtered by its level.
+func Component(name string) zerolog.Logger {
+	levels.mu.Lock()
+	root := levels.root
+	levels.mu.Unlock()
+
+	if name != ComponentGlobal {
+		root = root.With().Str("component", name).Logger()
+	}
+	return root.Hook(levelHook{component: name}, SpanHook{})
+}
+
+// levelHook drops lines below the current level of its component.
+type levelHook struct {
+	component string
+}
+
+func (h levelHook) Run(e *zerolog.Event, level zerolog.Level, _ string) {
+	if level == zerolog.NoLevel {
+		return
+	}
+	if level < levels.level(h.component) {
+		e.Discard()
+	}
+}
+
+// WatchLevels restores the configured levels on SIGUSR1 and then applies the
+// levels file, if any, until ctx is done. Each line of the file is
+// "component=level", "#" starts a comment. SIGHUP is left to the TLS
+// certificate reload, so rotating a certificate does not reset levels or
+// cancel pending reverts.
+func WatchLevels(ctx context.Context, file string) {
+	if levelsSignal == nil {
+		return
+	}
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, levelsSignal)
+	defer signal.Stop(reload)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reload:
+			if err := levels.reload(file); err != nil {
+				levels.mu.Lock()
+				root := levels.root
+				levels.mu.Unlock()
+				root.Error().Err(err).Str("file", file).Msg("failed to reload log levels")
+			}
+		}
+	}
+}
+
+func (r *levelRegistry) reload(file string) error {
+	r.mu.Lock()
+	wanted := copyLevels(r.configured)
+	r.mu.Unlock()
+
+	if file != "" {
+		fromFile, err := readLevelsFile(file)
+		if err != nil {
+			return err
+		}
+		for name, level := range fromFile {
+			wanted[name] = level
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range append([]string{ComponentGlobal}, Components...) {
+		current, hasCurrent := r.set[name]
+		level, ok := wanted[name]
+		_, pending := r.reverts[name]
+		if ok == hasCurrent && level == current && !pending {
+			continue
+		}
+
+		change := Change{Component: name, Level: LevelInherit, Actor: "signal", Source: "sighup"}
+		if ok {
+			change.Level = level.String()
+		}
+		r.update(change)
+	}
+	return nil
+}
+
+func readLevelsFile(path string) (map[string]zerolog.Level, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	result := map[string]zerolog.Level{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid log levels line %q", line)
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, known := levels.effective[name]; !known {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+		}
+		level, err := ParseLevel(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = level
+	}
+	return result, scanner.Err()
+}
+
+// ParseLevel accepts the levels of LoggingConfig: debug, info, warn and
+// error.
+func ParseLevel(value string) (zerolog.Level, error) {
+	switch strings.ToLower(value) {
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("invalid log level: %s (must be one of: debug, info, warn, error)", value)
+	}
+}
+
+func copyLevels(src map[string]zerolog.Level) map[string]zerolog.Level {
+	dst := make(map[string]zerolog.Level, len(src))
+	for name, level := range src {
+		dst[name] = level
+	}
+	return dst
+}
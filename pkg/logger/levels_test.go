@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestComponentSpanEvents(t *testing.T) {
+	tests := []struct {
+		name       string
+		components map[string]zerolog.Level
+		component  string
+		level      zerolog.Level
+		wantLine   bool
+		wantEvent  bool
+	}{
+		{
+			name:       "line below the component level",
+			components: map[string]zerolog.Level{ComponentDatabase: zerolog.DebugLevel},
+			component:  ComponentHTTP,
+			level:      zerolog.DebugLevel,
+		},
+		{
+			name:       "error line below the component level",
+			components: map[string]zerolog.Level{ComponentHTTP: zerolog.FatalLevel},
+			component:  ComponentHTTP,
+			level:      zerolog.ErrorLevel,
+		},
+		{
+			name:       "line at the component level",
+			components: map[string]zerolog.Level{ComponentDatabase: zerolog.DebugLevel},
+			component:  ComponentDatabase,
+			level:      zerolog.DebugLevel,
+			wantLine:   true,
+		},
+		{
+			name:      "error line",
+			component: ComponentHTTP,
+			level:     zerolog.ErrorLevel,
+			wantLine:  true,
+			wantEvent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() { levels = newLevelRegistry() })
+
+			var output bytes.Buffer
+			levels = newLevelRegistry()
+			levels.configure(zerolog.New(&output), zerolog.InfoLevel, tt.components)
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder))
+			ctx, span := provider.Tracer("logger").Start(context.Background(), "request")
+
+			logger := Component(tt.component)
+			logger.WithLevel(tt.level).Ctx(ctx).Msg("message")
+			span.End()
+
+			if gotLine := output.Len() > 0; gotLine != tt.wantLine {
+				t.Errorf("line written = %v, want %v: %s", gotLine, tt.wantLine, output.String())
+			}
+
+			events := recorder.Ended()[0].Events()
+			if gotEvent := len(events) > 0; gotEvent != tt.wantEvent {
+				t.Errorf("span events = %v, want event %v", events, tt.wantEvent)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,70 +10,110 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/pkgerrors"
 	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/pkg/redact"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
-func NewLoggerWithService(config *config.Monitor) (zerolog.Logger, error) {
-	var logLevel zerolog.Level
-	level := config.GetLogLevel()
-
-	switch level {
-	case "debug":
-		logLevel = zerolog.DebugLevel
-	case "info":
-		logLevel = zerolog.InfoLevel
-	case "warn":
-		logLevel = zerolog.WarnLevel
-	case "error":
-		logLevel = zerolog.ErrorLevel
-	default:
+// NewLoggerWithService builds the service logger writing to the configured
+// outputs. Close the returned closer on shutdown to stop the async writer and
+// close log files.
+func NewLoggerWithService(config *config.Monitor) (zerolog.Logger, io.Closer, error) {
+	logLevel, err := ParseLevel(config.GetLogLevel())
+	if err != nil {
 		logLevel = zerolog.InfoLevel
 	}
 
+	componentLevels := make(map[string]zerolog.Level, len(config.Logging.Components))
+	for name, value := range config.Logging.Components {
+		if _, ok := levels.effective[name]; !ok || name == ComponentGlobal {
+			return zerolog.New(os.Stdout), nil, fmt.Errorf("%w: %s", ErrUnknownComponent, name)
+		}
+		level, err := ParseLevel(value)
+		if err != nil {
+			return zerolog.New(os.Stdout), nil, err
+		}
+		componentLevels[name] = level
+	}
+
 	zerolog.TimeFieldFormat = "2006-01-02 15:04:05"
 	zerolog.ErrorStackMarshaler = pkgerrors.MarshalStack
 
-	var writer io.Writer
-
-	if err := os.MkdirAll("/var/lib/logs", 0o755); err != nil {
-		return zerolog.New(os.Stdout), err
+	out, err := newOutput(config.Logging)
+	if err != nil {
+		return zerolog.New(os.Stdout), nil, err
 	}
-	file, err := os.OpenFile("/var/lib/logs/app.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+
+	redactor, err := redact.New(config.Logging.Redaction)
 	if err != nil {
-		return zerolog.New(os.Stdout), err
+		_ = out.Close()
+		return zerolog.New(os.Stdout), nil, err
 	}
-	writer = io.Writer(file)
 
-	logger := zerolog.New(writer).
-		Level(logLevel).
+	// Levels are enforced per component by levelHook, see levels.go.
+	root := zerolog.New(redactor.Writer(out)).
+		Level(zerolog.TraceLevel).
 		With().
 		Timestamp().
 		Str("service", config.ServiceName).
 		Str("environment", config.Environment).
+		Stack().
 		Logger()
 
-	logger = logger.With().Stack().Logger()
+	levels.configure(root, logLevel, componentLevels)
 
-	return logger, nil
+	return Component(ComponentGlobal), out, nil
 }
 
-// WithTraceContext adds New Relic transaction context to logger
-func WithTraceContext(logger zerolog.Logger, span trace.Span) zerolog.Logger {
-	if span == nil {
+// WithTraceContext adds the ids of the span in ctx to logger, so log lines
+// can be joined to their trace. The logger keeps ctx for SpanHook.
+func WithTraceContext(ctx context.Context, logger zerolog.Logger) zerolog.Logger {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
 		return logger
 	}
 
-	// Get trace metadata from transaction
-	spanContext := span.SpanContext()
-
 	return logger.With().
+		Ctx(ctx).
 		Str("trace.id", spanContext.TraceID().String()).
 		Str("span.id", spanContext.SpanID().String()).
 		Logger()
 }
 
-// NewPgxLogger creates a database logger
-func NewPgxLogger(level zerolog.Level) zerolog.Logger {
+// FromContext returns the request logger stored in ctx, or fallback when
+// ctx carries none, as in background jobs.
+func FromContext(ctx context.Context, fallback *zerolog.Logger) *zerolog.Logger {
+	if logger := zerolog.Ctx(ctx); logger.GetLevel() != zerolog.Disabled {
+		return logger
+	}
+	return fallback
+}
+
+// SpanHook records error level log lines as events on the span of the
+// logger's context, so a trace shows what was logged while it ran.
+type SpanHook struct{}
+
+func (SpanHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
+	// A line discarded by an earlier hook, such as levelHook, arrives here as
+	// Disabled, which sorts above the error levels.
+	if level < zerolog.ErrorLevel || level == zerolog.NoLevel || level == zerolog.Disabled {
+		return
+	}
+
+	span := trace.SpanFromContext(e.GetCtx())
+	if !span.IsRecording() {
+		return
+	}
+
+	span.AddEvent("log", trace.WithAttributes(
+		attribute.String("log.severity", level.String()),
+		attribute.String("log.message", msg),
+	))
+}
+
+// NewPgxLogger creates a database logger following the database component
+// level, query arguments pass the redactor before they are printed.
+func NewPgxLogger(redactor *redact.Redactor) zerolog.Logger {
 	writer := zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: "2006-01-02 15:04:05",
@@ -98,26 +139,11 @@ func NewPgxLogger(level zerolog.Level) zerolog.Logger {
 		},
 	}
 
-	return zerolog.New(writer).
-		Level(level).
+	return zerolog.New(redactor.Writer(writer)).
+		Level(zerolog.TraceLevel).
 		With().
 		Timestamp().
-		Str("component", "database").
-		Logger()
-}
-
-// GetPgxTraceLogLevel converts zerolog level to pgx tracelog level
-func GetPgxTraceLogLevel(level zerolog.Level) int {
-	switch level {
-	case zerolog.DebugLevel:
-		return 6 // tracelog.LogLevelDebug
-	case zerolog.InfoLevel:
-		return 4 // tracelog.LogLevelInfo
-	case zerolog.WarnLevel:
-		return 3 // tracelog.LogLevelWarn
-	case zerolog.ErrorLevel:
-		return 2 // tracelog.LogLevelError
-	default:
-		return 0 // tracelog.LogLevelNone
-	}
+		Str("component", ComponentDatabase).
+		Logger().
+		Hook(levelHook{component: ComponentDatabase})
 }
@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/diode"
+	"github.com/shanto-323/backend-scaffold/config"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// dropped counts lines the async writer discarded because the outputs fell
+// behind.
+var dropped atomic.Uint64
+
+// DroppedLines reports how many log lines were dropped since start.
+func DroppedLines() uint64 {
+	return dropped.Load()
+}
+
+// output fans formatted lines out to every configured sink.
+type output struct {
+	writer  io.Writer
+	closers []io.Closer
+}
+
+// newOutput opens the sinks of cfg. Lines arrive as zerolog JSON and are
+// formatted per sink, asynchronously unless cfg.Async is disabled.
+func newOutput(cfg config.LoggingConfig) (*output, error) {
+	o := &output{}
+	writers := make([]io.Writer, 0, len(cfg.Outputs))
+
+	for _, target := range cfg.Outputs {
+		var sink io.Writer
+		terminal := false
+
+		switch target {
+		case config.LogOutputStdout:
+			sink, terminal = os.Stdout, true
+		case config.LogOutputStderr:
+			sink, terminal = os.Stderr, true
+		default:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				_ = o.Close()
+				return nil, err
+			}
+			file := &lumberjack.Logger{
+				Filename:   target,
+				MaxSize:    cfg.Rotation.MaxSizeMB,
+				MaxAge:     int(math.Ceil(cfg.Rotation.MaxAge.Hours() / 24)),
+				MaxBackups: cfg.Rotation.MaxBackups,
+				Compress:   !cfg.Rotation.DisableCompression,
+			}
+			sink = file
+			o.closers = append(o.closers, file)
+		}
+
+		writers = append(writers, formatWriter(cfg.Format, sink, terminal))
+	}
+
+	o.writer = zerolog.MultiLevelWriter(writers...)
+	if !cfg.Async.Disabled {
+		// The diode closes what it wraps, hide Close so files are closed
+		// once, after the diode stopped writing to them.
+		async := diode.NewWriter(struct{ io.Writer }{o.writer}, cfg.Async.BufferSize, cfg.Async.PollInterval, func(missed int) {
+			dropped.Add(uint64(missed))
+		})
+		o.writer = async
+		o.closers = append([]io.Closer{async}, o.closers...)
+	}
+
+	return o, nil
+}
+
+func (o *output) Write(p []byte) (int, error) {
+	return o.writer.Write(p)
+}
+
+// Close stops the async writer and closes file sinks.
+func (o *output) Close() error {
+	var errs []error
+	for _, c := range o.closers {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func formatWriter(format string, sink io.Writer, terminal bool) io.Writer {
+	switch format {
+	case config.LogFormatConsole:
+		return zerolog.ConsoleWriter{
+			Out:        sink,
+			NoColor:    !terminal,
+			TimeFormat: zerolog.TimeFieldFormat,
+		}
+	case config.LogFormatLogfmt:
+		return zerolog.ConsoleWriter{
+			Out:        sink,
+			NoColor:    true,
+			TimeFormat: zerolog.TimeFieldFormat,
+			PartsOrder: []string{zerolog.TimestampFieldName, zerolog.LevelFieldName, zerolog.MessageFieldName},
+			FormatTimestamp: func(i any) string {
+				return "time=" + logfmtValue(i)
+			},
+			FormatLevel: func(i any) string {
+				return "level=" + logfmtValue(i)
+			},
+			FormatMessage: func(i any) string {
+				return "msg=" + logfmtValue(i)
+			},
+			FormatFieldName: func(i any) string {
+				return fmt.Sprintf("%s=", i)
+			},
+			FormatFieldValue: logfmtValue,
+			FormatErrFieldName: func(i any) string {
+				return fmt.Sprintf("%s=", i)
+			},
+			FormatErrFieldValue: logfmtValue,
+		}
+	default:
+		return sink
+	}
+}
+
+// logfmtValue quotes values holding spaces, quotes or "=". ConsoleWriter
+// hands over such field values already quoted, they are kept as they are.
+func logfmtValue(i any) string {
+	var s string
+	switch v := i.(type) {
+	case nil:
+		s = ""
+	case []byte:
+		s = string(v)
+	case time.Time:
+		s = v.Format(zerolog.TimeFieldFormat)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if _, err := strconv.Unquote(s); err == nil && strings.HasPrefix(s, `"`) {
+		return s
+	}
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,11 @@
+//go:build !windows
+
+package logger
+
+import (
+	"os"
+	"syscall"
+)
+
+// levelsSignal makes WatchLevels reload the log levels.
+var levelsSignal os.Signal = syscall.SIGUSR1
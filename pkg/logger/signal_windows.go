@@ -0,0 +1,7 @@
+package logger
+
+import "os"
+
+// levelsSignal is nil because Windows has no SIGUSR1. Levels can still be
+// changed through the admin API.
+var levelsSignal os.Signal
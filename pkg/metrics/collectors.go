@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// PgxPoolCollector reads pgxpool stats on every scrape.
+type PgxPoolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquired      *prometheus.Desc
+	idle          *prometheus.Desc
+	total         *prometheus.Desc
+	max           *prometheus.Desc
+	acquires      *prometheus.Desc
+	acquireTime   *prometheus.Desc
+	emptyAcquires *prometheus.Desc
+	canceled      *prometheus.Desc
+}
+
+func NewPgxPoolCollector(namespace string, stat func() *pgxpool.Stat) *PgxPoolCollector {
+	name := func(n string) string {
+		return prometheus.BuildFQName(namespace, "pgx_pool", n)
+	}
+
+	return &PgxPoolCollector{
+		stat:          stat,
+		acquired:      prometheus.NewDesc(name("acquired_conns"), "Connections currently in use.", nil, nil),
+		idle:          prometheus.NewDesc(name("idle_conns"), "Idle connections.", nil, nil),
+		total:         prometheus.NewDesc(name("total_conns"), "Open connections.", nil, nil),
+		max:           prometheus.NewDesc(name("max_conns"), "Maximum pool size.", nil, nil),
+		acquires:      prometheus.NewDesc(name("acquires_total"), "Successful connection acquires.", nil, nil),
+		acquireTime:   prometheus.NewDesc(name("acquire_seconds_total"), "Time spent waiting for connections.", nil, nil),
+		emptyAcquires: prometheus.NewDesc(name("empty_acquires_total"), "Acquires that had to wait for a connection.", nil, nil),
+		canceled:      prometheus.NewDesc(name("canceled_acquires_total"), "Acquires cancelled by their context.", nil, nil),
+	}
+}
+
+func (c *PgxPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *PgxPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.stat()
+	if stat == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireTime, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceled, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+}
+
+// RedisPoolCollector reads go-redis pool stats on every scrape.
+type RedisPoolCollector struct {
+	stats func() *redis.PoolStats
+
+	hits     *prometheus.Desc
+	misses   *prometheus.Desc
+	timeouts *prometheus.Desc
+	total    *prometheus.Desc
+	idle     *prometheus.Desc
+	stale    *prometheus.Desc
+}
+
+func NewRedisPoolCollector(namespace string, stats func() *redis.PoolStats) *RedisPoolCollector {
+	name := func(n string) string {
+		return prometheus.BuildFQName(namespace, "redis_pool", n)
+	}
+
+	return &RedisPoolCollector{
+		stats:    stats,
+		hits:     prometheus.NewDesc(name("hits_total"), "Times a free connection was found in the pool.", nil, nil),
+		misses:   prometheus.NewDesc(name("misses_total"), "Times no free connection was found in the pool.", nil, nil),
+		timeouts: prometheus.NewDesc(name("timeouts_total"), "Times waiting for a connection timed out.", nil, nil),
+		total:    prometheus.NewDesc(name("total_conns"), "Open connections.", nil, nil),
+		idle:     prometheus.NewDesc(name("idle_conns"), "Idle connections.", nil, nil),
+		stale:    prometheus.NewDesc(name("stale_conns_total"), "Stale connections removed from the pool.", nil, nil),
+	}
+}
+
+func (c *RedisPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *RedisPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+	if stats == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(stats.StaleConns))
+}
+
+// NewLogDropsCollector exposes the lines the async log writer dropped.
+func NewLogDropsCollector(namespace string, dropped func() uint64) prometheus.Collector {
+	return prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "log_lines_dropped_total",
+		Help:      "Log lines dropped because the log outputs fell behind.",
+	}, func() float64 {
+		return float64(dropped())
+	})
+}
@@ -0,0 +1,136 @@
+// Package metrics keeps the Prometheus registry of the service: RED metrics
+// per route, Redis command latency, connection pool stats and Go runtime
+// metrics.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shanto-323/backend-scaffold/config"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+
+	redisDuration *prometheus.HistogramVec
+	cacheReads    *prometheus.CounterVec
+}
+
+// redisBuckets are finer than the HTTP ones, a healthy command takes well
+// under a millisecond.
+var redisBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1}
+
+func New(config *config.Config) (*Metrics, error) {
+	namespace := config.Monitor.Metrics.Namespace
+	labels := []string{"route", "method", "status"}
+
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by route template, method and status.",
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_request_errors_total",
+			Help:      "HTTP requests answered with a 5xx status.",
+		}, labels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by route template, method and status.",
+			Buckets:   config.Monitor.Metrics.DurationBuckets,
+		}, labels),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "HTTP requests currently being served.",
+		}),
+		redisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "redis_command_duration_seconds",
+			Help:      "Redis command latency by command and status, pipelines count as one.",
+			Buckets:   redisBuckets,
+		}, []string{"command", "status"}),
+		cacheReads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_reads_total",
+			Help:      "Cache reads by result, hit or miss.",
+		}, []string{"result"}),
+	}
+
+	if err := m.Register(
+		m.requests,
+		m.errors,
+		m.duration,
+		m.inFlight,
+		m.redisDuration,
+		m.cacheReads,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{Namespace: namespace}),
+	); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Metrics) Register(cs ...prometheus.Collector) error {
+	for _, c := range cs {
+		if err := m.registry.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler serves the registry in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// StartRequest counts an in-flight request, the returned func records it.
+func (m *Metrics) StartRequest() func(route, method string, status int) {
+	start := time.Now()
+	m.inFlight.Inc()
+
+	return func(route, method string, status int) {
+		m.inFlight.Dec()
+
+		code := strconv.Itoa(status)
+		m.requests.WithLabelValues(route, method, code).Inc()
+		m.duration.WithLabelValues(route, method, code).Observe(time.Since(start).Seconds())
+		if status >= http.StatusInternalServerError {
+			m.errors.WithLabelValues(route, method, code).Inc()
+		}
+	}
+}
+
+// ObserveRedisCommand records the latency of a Redis command or pipeline.
+func (m *Metrics) ObserveRedisCommand(command string, failed bool, d time.Duration) {
+	status := "ok"
+	if failed {
+		status = "error"
+	}
+	m.redisDuration.WithLabelValues(command, status).Observe(d.Seconds())
+}
+
+// CacheRead counts a cache lookup, the hit ratio is hits over all reads.
+func (m *Metrics) CacheRead(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheReads.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,286 @@
+// Package mockidp is an in-process OpenID Connect provider for local
+// development and tests. It approves every authorization request, so it must
+// never be enabled in production.
+package mockidp
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/shanto-323/backend-scaffold/pkg/oidc"
+)
+
+const (
+	codeTTL  = time.Minute
+	tokenTTL = time.Hour
+
+	DefaultSubject = "mock-user"
+	// DefaultRole is the least-privileged role; pass mock_role to log in as
+	// staff or admin.
+	DefaultRole = "user"
+)
+
+type signingKey struct {
+	kid     string
+	private *rsa.PrivateKey
+}
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	subject       string
+	role          string
+	tenant        string
+	expiresAt     time.Time
+}
+
+// IdP serves discovery, authorize, token and JWKS endpoints under its issuer.
+// The authorize endpoint logs in the user named by the login_hint query
+// parameter (role and tenant come from mock_role and mock_tenant).
+type IdP struct {
+	issuer string
+	mux    *http.ServeMux
+
+	mu    sync.Mutex
+	keys  []signingKey
+	codes map[string]authorization
+}
+
+func New(issuer string) (*IdP, error) {
+	idp := &IdP{
+		issuer: strings.TrimSuffix(issuer, "/"),
+		mux:    http.NewServeMux(),
+		codes:  map[string]authorization{},
+	}
+
+	if err := idp.Rotate(); err != nil {
+		return nil, err
+	}
+
+	idp.mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	idp.mux.HandleFunc("GET /authorize", idp.authorize)
+	idp.mux.HandleFunc("POST /token", idp.token)
+	idp.mux.HandleFunc("GET /jwks", idp.jwks)
+
+	return idp, nil
+}
+
+func (idp *IdP) Issuer() string {
+	return idp.issuer
+}
+
+// ServeHTTP expects paths relative to the issuer, e.g. "/jwks".
+func (idp *IdP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	idp.mux.ServeHTTP(w, r)
+}
+
+// Rotate adds a new signing key. The previous key stays published so tokens
+// it signed remain valid, like a real provider during rotation.
+func (idp *IdP) Rotate() error {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return fmt.Errorf("mock idp: failed to generate key: %w", err)
+	}
+	kid, err := oidc.RandomString()
+	if err != nil {
+		return err
+	}
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	idp.keys = append([]signingKey{{kid: kid[:16], private: private}}, idp.keys...)
+	if len(idp.keys) > 2 {
+		idp.keys = idp.keys[:2]
+	}
+	return nil
+}
+
+// Transport routes requests for the issuer to the IdP in memory, so the
+// relying party never touches the network.
+func (idp *IdP) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{idp: idp, next: next}
+}
+
+func (idp *IdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidc.Discovery{
+		Issuer:                idp.issuer,
+		AuthorizationEndpoint: idp.issuer + "/authorize",
+		TokenEndpoint:         idp.issuer + "/token",
+		JWKSURI:               idp.issuer + "/jwks",
+	})
+}
+
+func (idp *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "mock idp: only the code flow with S256 PKCE is supported", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "mock idp: invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code, err := oidc.RandomString()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+
+	idp.mu.Lock()
+	// Codes that are never exchanged would otherwise pile up.
+	for issued, auth := range idp.codes {
+		if now.After(auth.expiresAt) {
+			delete(idp.codes, issued)
+		}
+	}
+	idp.codes[code] = authorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		subject:       valueOr(query.Get("login_hint"), DefaultSubject),
+		role:          valueOr(query.Get("mock_role"), DefaultRole),
+		tenant:        query.Get("mock_tenant"),
+		expiresAt:     now.Add(codeTTL),
+	}
+	idp.mu.Unlock()
+
+	callback := redirectURI.Query()
+	callback.Set("code", code)
+	callback.Set("state", query.Get("state"))
+	redirectURI.RawQuery = callback.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (idp *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+
+	idp.mu.Lock()
+	auth, ok := idp.codes[code]
+	delete(idp.codes, code)
+	key := idp.keys[0]
+	idp.mu.Unlock()
+
+	clientID := r.PostForm.Get("client_id")
+	if user, _, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(user)
+	}
+
+	if !ok || time.Now().After(auth.expiresAt) ||
+		auth.clientID != clientID ||
+		auth.redirectURI != r.PostForm.Get("redirect_uri") ||
+		auth.codeChallenge != oidc.CodeChallenge(r.PostForm.Get("code_verifier")) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   idp.issuer,
+		"sub":   auth.subject,
+		"aud":   auth.clientID,
+		"iat":   now.Unix(),
+		"exp":   now.Add(tokenTTL).Unix(),
+		"nonce": auth.nonce,
+		"email": auth.subject + "@mock.local",
+		"role":  auth.role,
+	}
+	if auth.tenant != "" {
+		claims["tenant_id"] = auth.tenant
+	}
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = key.kid
+	signed, err := idToken.SignedString(key.private)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	accessToken, err := oidc.RandomString()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, oidc.Token{
+		AccessToken: accessToken,
+		IDToken:     signed,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(tokenTTL.Seconds()),
+	})
+}
+
+func (idp *IdP) jwks(w http.ResponseWriter, r *http.Request) {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	set := oidc.JSONWebKeySet{}
+	for _, key := range idp.keys {
+		set.Keys = append(set.Keys, oidc.NewRSAJSONWebKey(key.kid, &key.private.PublicKey))
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+type transport struct {
+	idp  *IdP
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	path, ok := strings.CutPrefix(r.URL.String(), t.idp.issuer)
+	if !ok {
+		return t.next.RoundTrip(r)
+	}
+
+	inner := r.Clone(r.Context())
+	inner.URL.Path, inner.URL.RawQuery, _ = strings.Cut(path, "?")
+	inner.RequestURI = ""
+
+	recorder := httptest.NewRecorder()
+	t.idp.ServeHTTP(recorder, inner)
+
+	resp := recorder.Result()
+	resp.Request = r
+	return resp, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,256 @@
+package mockidp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/shanto-323/backend-scaffold/pkg/oidc"
+)
+
+const (
+	issuer      = "https://idp.mock.local"
+	clientID    = "backend"
+	redirectURL = "https://app.local/api/v1/auth/callback"
+)
+
+// failTransport catches requests that would leave the process.
+type failTransport struct{}
+
+func (failTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	return nil, errors.New("unexpected network request to " + r.URL.String())
+}
+
+// relyingParty reaches the IdP only through its Transport. Redirects are not
+// followed so the browser leg can stop at the callback URL.
+type relyingParty struct {
+	client   *http.Client
+	provider *oidc.Provider
+}
+
+func newRelyingParty(idp *IdP) *relyingParty {
+	client := &http.Client{
+		Transport: idp.Transport(failTransport{}),
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	return &relyingParty{
+		client: client,
+		provider: oidc.New(oidc.Config{
+			IssuerURL:   issuer,
+			ClientID:    clientID,
+			RedirectURL: redirectURL,
+			HTTPClient:  client,
+		}),
+	}
+}
+
+// login follows the authorization URL to the callback, checks the state and
+// exchanges the code. It returns the ID token and the nonce it must carry.
+func (rp *relyingParty) login(t *testing.T, params url.Values) (string, string) {
+	t.Helper()
+	ctx := context.Background()
+
+	state, err := oidc.RandomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce, err := oidc.RandomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authURL, err := rp.provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	if len(params) > 0 {
+		authURL += "&" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, authURL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := rp.client.Do(req)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+
+	callback, err := resp.Location()
+	if err != nil {
+		t.Fatalf("authorize location: %v", err)
+	}
+	query := callback.Query()
+	callback.RawQuery = ""
+	if callback.String() != redirectURL {
+		t.Fatalf("callback = %s, want %s", callback, redirectURL)
+	}
+	if query.Get("state") != state {
+		t.Fatalf("callback state = %q, want %q", query.Get("state"), state)
+	}
+
+	token, err := rp.provider.Exchange(ctx, query.Get("code"), verifier)
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	return token.IDToken, nonce
+}
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     url.Values
+		wantSub    string
+		wantRole   string
+		wantTenant string
+	}{
+		{
+			name:     "defaults",
+			wantSub:  DefaultSubject,
+			wantRole: DefaultRole,
+		},
+		{
+			name: "login hint",
+			params: url.Values{
+				"login_hint":  {"rahim"},
+				"mock_role":   {"admin"},
+				"mock_tenant": {"school-a"},
+			},
+			wantSub:    "rahim",
+			wantRole:   "admin",
+			wantTenant: "school-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp, err := New(issuer)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			rp := newRelyingParty(idp)
+
+			idToken, nonce := rp.login(t, tt.params)
+			claims, err := rp.provider.Verify(context.Background(), idToken, nonce)
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			if sub, _ := claims["sub"].(string); sub != tt.wantSub {
+				t.Errorf("sub = %q, want %q", sub, tt.wantSub)
+			}
+			if role, _ := claims["role"].(string); role != tt.wantRole {
+				t.Errorf("role = %q, want %q", role, tt.wantRole)
+			}
+			if tenant, _ := claims["tenant_id"].(string); tenant != tt.wantTenant {
+				t.Errorf("tenant_id = %q, want %q", tenant, tt.wantTenant)
+			}
+
+			if _, err := rp.provider.Verify(context.Background(), idToken, "other-nonce"); !errors.Is(err, oidc.ErrInvalidToken) {
+				t.Errorf("Verify with another nonce = %v, want %v", err, oidc.ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestCodeIsSingleUse(t *testing.T) {
+	idp, err := New(issuer)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rp := newRelyingParty(idp)
+	ctx := context.Background()
+
+	verifier, err := oidc.RandomString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	authURL, err := rp.provider.AuthCodeURL(ctx, "state", "nonce", oidc.CodeChallenge(verifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	resp, err := rp.client.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	callback, err := resp.Location()
+	if err != nil {
+		t.Fatalf("authorize location: %v", err)
+	}
+	code := callback.Query().Get("code")
+
+	if _, err := rp.provider.Exchange(ctx, code, "wrong-verifier"); err == nil {
+		t.Fatal("Exchange with the wrong verifier succeeded")
+	}
+	if _, err := rp.provider.Exchange(ctx, code, verifier); err == nil {
+		t.Fatal("Exchange reused a code")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	idp, err := New(issuer)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	first, firstNonce := newRelyingParty(idp).login(t, nil)
+
+	if err := idp.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	second, secondNonce := newRelyingParty(idp).login(t, nil)
+
+	// A relying party that fetches the JWKS after the rotation trusts tokens
+	// signed with either key.
+	rp := newRelyingParty(idp)
+	if _, err := rp.provider.Verify(context.Background(), first, firstNonce); err != nil {
+		t.Errorf("Verify token signed before rotation: %v", err)
+	}
+	if _, err := rp.provider.Verify(context.Background(), second, secondNonce); err != nil {
+		t.Errorf("Verify token signed after rotation: %v", err)
+	}
+
+	// A second rotation retires the first key.
+	if err := idp.Rotate(); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	rp = newRelyingParty(idp)
+	if _, err := rp.provider.Verify(context.Background(), first, firstNonce); !errors.Is(err, oidc.ErrInvalidToken) {
+		t.Errorf("Verify token signed with a retired key = %v, want %v", err, oidc.ErrInvalidToken)
+	}
+	if _, err := rp.provider.Verify(context.Background(), second, secondNonce); err != nil {
+		t.Errorf("Verify token signed with the previous key: %v", err)
+	}
+}
+
+func TestExpiredCodesArePruned(t *testing.T) {
+	idp, err := New(issuer)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	idp.codes["expired"] = authorization{expiresAt: time.Now().Add(-time.Second)}
+	idp.codes["live"] = authorization{expiresAt: time.Now().Add(codeTTL)}
+
+	newRelyingParty(idp).login(t, nil)
+
+	if _, ok := idp.codes["expired"]; ok {
+		t.Error("expired code was not pruned")
+	}
+	if _, ok := idp.codes["live"]; !ok {
+		t.Error("unexpired code was pruned")
+	}
+}
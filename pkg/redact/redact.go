@@ -0,0 +1,94 @@
+// Package redact scrubs personal data from log lines and span attributes.
+// Values of sensitive fields are replaced whole, regular expressions catch
+// the same data inside free text such as error messages.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shanto-323/backend-scaffold/config"
+)
+
+type Redactor struct {
+	fields      map[string]struct{}
+	patterns    []*regexp.Regexp
+	replacement string
+}
+
+// New returns nil when redaction is disabled, every method of a nil
+// Redactor leaves its input untouched.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	r := &Redactor{
+		fields:      make(map[string]struct{}, len(cfg.Fields)),
+		replacement: cfg.Replacement,
+	}
+
+	for _, field := range cfg.Fields {
+		r.fields[normalizeField(field)] = struct{}{}
+	}
+
+	for _, pattern := range cfg.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redact: invalid pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// Sensitive reports whether values of the field are always redacted. The
+// whole key is matched, so "db.name" and "service.name" are not "name", a
+// dotted attribute has to be listed as such.
+func (r *Redactor) Sensitive(field string) bool {
+	if r == nil {
+		return false
+	}
+
+	_, ok := r.fields[normalizeField(field)]
+	return ok
+}
+
+// String replaces every pattern match in s.
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.patterns {
+		s = re.ReplaceAllLiteralString(s, r.replacement)
+	}
+	return s
+}
+
+// Field returns the value to keep for a string field.
+func (r *Redactor) Field(field, value string) string {
+	if r.Sensitive(field) {
+		return r.replacement
+	}
+	return r.String(value)
+}
+
+func (r *Redactor) Replacement() string {
+	if r == nil {
+		return ""
+	}
+	return r.replacement
+}
+
+func normalizeField(field string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.':
+			return -1
+		}
+		return r
+	}, strings.ToLower(field))
+}
@@ -0,0 +1,237 @@
+package redact_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/pkg/redact"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const replacement = "[REDACTED]"
+
+// newRedactor builds a redactor from the built-in defaults plus extra fields.
+func newRedactor(t *testing.T, extraFields ...string) *redact.Redactor {
+	t.Helper()
+
+	monitor := &config.Monitor{}
+	monitor.ApplyDefaults()
+
+	cfg := monitor.Logging.Redaction
+	cfg.Fields = append(cfg.Fields, extraFields...)
+
+	r, err := redact.New(cfg)
+	if err != nil {
+		t.Fatalf("redact.New: %v", err)
+	}
+	return r
+}
+
+func TestLine(t *testing.T) {
+	r := newRedactor(t)
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{
+			name: "sensitive keys",
+			line: `{"address":"12 Lake Road","email":"rahim@example.com","guardian_contact":"01812345678","level":"info","name":"Rahim","phone":"01712345678"}` + "\n",
+			want: `{"address":"[REDACTED]","email":"[REDACTED]","guardian_contact":"[REDACTED]","level":"info","name":"[REDACTED]","phone":"[REDACTED]"}` + "\n",
+		},
+		{
+			name: "key spelling",
+			line: `{"Guardian-Contact":"x","guardianContact":"y","PHONE":"z"}`,
+			want: `{"Guardian-Contact":"[REDACTED]","PHONE":"[REDACTED]","guardianContact":"[REDACTED]"}`,
+		},
+		{
+			name: "nested keys",
+			line: `{"student":{"address":"12 Lake Road","guardian_contact":"x","id":7,"name":"Rahim"},"students":[{"email":"a@b.co","phone":"y"}]}`,
+			want: `{"student":{"address":"[REDACTED]","guardian_contact":"[REDACTED]","id":7,"name":"[REDACTED]"},"students":[{"email":"[REDACTED]","phone":"[REDACTED]"}]}`,
+		},
+		{
+			name: "free text",
+			line: `{"error":"no guardian at +8801712345678 or 01912345678","message":"mail rahim@example.com or +14155550100"}`,
+			want: `{"error":"no guardian at [REDACTED] or [REDACTED]","message":"mail [REDACTED] or [REDACTED]"}`,
+		},
+		{
+			name: "dotted keys are matched whole",
+			line: `{"db.name":"school","service.name":"backend"}`,
+			want: `{"db.name":"school","service.name":"backend"}`,
+		},
+		{
+			name: "ids keep their digits",
+			line: `{"request_id":"3f2b01c4-8801-4b2a-9c1d-017123456789","trace_id":"a01712345678b34da6a3ce929d0e0e47"}`,
+			want: `{"request_id":"3f2b01c4-8801-4b2a-9c1d-017123456789","trace_id":"a01712345678b34da6a3ce929d0e0e47"}`,
+		},
+		{
+			name: "console line",
+			line: "INF created student phone=01712345678 email=rahim@example.com\n",
+			want: "INF created student phone=[REDACTED] email=[REDACTED]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(r.Line([]byte(tt.line))); got != tt.want {
+				t.Errorf("Line()\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	r := newRedactor(t, "student.phone")
+
+	tests := []struct {
+		name string
+		attr attribute.KeyValue
+		want attribute.KeyValue
+	}{
+		{"name", attribute.String("name", "Rahim"), attribute.String("name", replacement)},
+		{"phone", attribute.String("phone", "01712345678"), attribute.String("phone", replacement)},
+		{"address", attribute.String("address", "12 Lake Road"), attribute.String("address", replacement)},
+		{"guardian contact", attribute.String("guardian_contact", "x"), attribute.String("guardian_contact", replacement)},
+		{"email", attribute.String("email", "rahim@example.com"), attribute.String("email", replacement)},
+		{"non string value", attribute.Int("phone", 1712345678), attribute.String("phone", replacement)},
+		{"listed nested key", attribute.String("student.phone", "x"), attribute.String("student.phone", replacement)},
+		{"db name", attribute.String("db.name", "school"), attribute.String("db.name", "school")},
+		{"service name", attribute.String("service.name", "backend"), attribute.String("service.name", "backend")},
+		{
+			"free text",
+			attribute.String("exception.message", "no student with email rahim@example.com or phone 01712345678"),
+			attribute.String("exception.message", "no student with email [REDACTED] or phone [REDACTED]"),
+		},
+		{
+			"string slice",
+			attribute.StringSlice("recipients", []string{"rahim@example.com", "school"}),
+			attribute.StringSlice("recipients", []string{replacement, "school"}),
+		},
+		{
+			"trace id",
+			attribute.String("trace_id", "a01712345678b34da6a3ce929d0e0e47"),
+			attribute.String("trace_id", "a01712345678b34da6a3ce929d0e0e47"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Attributes([]attribute.KeyValue{tt.attr})
+			if !reflect.DeepEqual(got, []attribute.KeyValue{tt.want}) {
+				t.Errorf("Attributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExporter(t *testing.T) {
+	tests := []struct {
+		name      string
+		attrs     []attribute.KeyValue
+		event     []attribute.KeyValue
+		err       error
+		status    string
+		wantAttrs []attribute.KeyValue
+		wantEvent []attribute.KeyValue
+		wantError string
+		wantDesc  string
+	}{
+		{
+			name: "sensitive attributes",
+			attrs: []attribute.KeyValue{
+				attribute.String("name", "Rahim"),
+				attribute.String("phone", "01712345678"),
+				attribute.String("address", "12 Lake Road"),
+				attribute.String("guardian_contact", "01812345678"),
+				attribute.String("email", "rahim@example.com"),
+				attribute.String("db.name", "school"),
+			},
+			event: []attribute.KeyValue{attribute.String("guardian_contact", "x")},
+			wantAttrs: []attribute.KeyValue{
+				attribute.String("name", replacement),
+				attribute.String("phone", replacement),
+				attribute.String("address", replacement),
+				attribute.String("guardian_contact", replacement),
+				attribute.String("email", replacement),
+				attribute.String("db.name", "school"),
+			},
+			wantEvent: []attribute.KeyValue{attribute.String("guardian_contact", replacement)},
+		},
+		{
+			name:      "errors and status",
+			err:       errors.New("duplicate email rahim@example.com"),
+			status:    "guardian 01712345678 unreachable",
+			wantError: "duplicate email [REDACTED]",
+			wantDesc:  "guardian [REDACTED] unreachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewInMemoryExporter()
+			provider := tracesdk.NewTracerProvider(tracesdk.WithSyncer(newRedactor(t).Exporter(recorder)))
+			defer func() { _ = provider.Shutdown(context.Background()) }()
+
+			_, span := provider.Tracer("redact").Start(context.Background(), "span", trace.WithAttributes(tt.attrs...))
+			if tt.event != nil {
+				span.AddEvent("event", trace.WithAttributes(tt.event...))
+			}
+			if tt.err != nil {
+				span.RecordError(tt.err)
+			}
+			if tt.status != "" {
+				span.SetStatus(codes.Error, tt.status)
+			}
+			span.End()
+
+			spans := recorder.GetSpans()
+			if len(spans) != 1 {
+				t.Fatalf("exported %d spans, want 1", len(spans))
+			}
+			exported := spans[0]
+
+			if !reflect.DeepEqual(exported.Attributes, tt.wantAttrs) {
+				t.Errorf("attributes = %v, want %v", exported.Attributes, tt.wantAttrs)
+			}
+			if tt.wantEvent != nil && !reflect.DeepEqual(exported.Events[0].Attributes, tt.wantEvent) {
+				t.Errorf("event attributes = %v, want %v", exported.Events[0].Attributes, tt.wantEvent)
+			}
+			if tt.wantError != "" {
+				message := ""
+				for _, attr := range exported.Events[0].Attributes {
+					if attr.Key == "exception.message" {
+						message = attr.Value.AsString()
+					}
+				}
+				if message != tt.wantError {
+					t.Errorf("exception.message = %q, want %q", message, tt.wantError)
+				}
+			}
+			if exported.Status.Description != tt.wantDesc {
+				t.Errorf("status description = %q, want %q", exported.Status.Description, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	var r *redact.Redactor
+
+	line := []byte(`{"phone":"01712345678"}`)
+	if got := r.Line(line); string(got) != string(line) {
+		t.Errorf("Line() = %s, want input unchanged", got)
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("phone", "01712345678")}
+	if got := r.Attributes(attrs); !reflect.DeepEqual(got, attrs) {
+		t.Errorf("Attributes() = %v, want input unchanged", got)
+	}
+}
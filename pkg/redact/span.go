@@ -0,0 +1,88 @@
+package redact
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Exporter redacts span attributes, event attributes (exception messages
+// included) and status descriptions before next exports them.
+func (r *Redactor) Exporter(next tracesdk.SpanExporter) tracesdk.SpanExporter {
+	if r == nil {
+		return next
+	}
+	return &exporter{redactor: r, next: next}
+}
+
+type exporter struct {
+	redactor *Redactor
+	next     tracesdk.SpanExporter
+}
+
+func (e *exporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	redacted := make([]tracesdk.ReadOnlySpan, len(spans))
+	for i, span := range spans {
+		redacted[i] = &redactedSpan{ReadOnlySpan: span, redactor: e.redactor}
+	}
+	return e.next.ExportSpans(ctx, redacted)
+}
+
+func (e *exporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
+
+// redactedSpan overrides the parts of a finished span that carry free text.
+type redactedSpan struct {
+	tracesdk.ReadOnlySpan
+	redactor *Redactor
+}
+
+func (s *redactedSpan) Attributes() []attribute.KeyValue {
+	return s.redactor.Attributes(s.ReadOnlySpan.Attributes())
+}
+
+func (s *redactedSpan) Events() []tracesdk.Event {
+	events := s.ReadOnlySpan.Events()
+	redacted := make([]tracesdk.Event, len(events))
+	for i, event := range events {
+		event.Name = s.redactor.String(event.Name)
+		event.Attributes = s.redactor.Attributes(event.Attributes)
+		redacted[i] = event
+	}
+	return redacted
+}
+
+func (s *redactedSpan) Status() tracesdk.Status {
+	status := s.ReadOnlySpan.Status()
+	status.Description = s.redactor.String(status.Description)
+	return status
+}
+
+// Attributes returns a redacted copy of attrs.
+func (r *Redactor) Attributes(attrs []attribute.KeyValue) []attribute.KeyValue {
+	if r == nil || len(attrs) == 0 {
+		return attrs
+	}
+
+	redacted := make([]attribute.KeyValue, len(attrs))
+	for i, attr := range attrs {
+		key := string(attr.Key)
+		switch {
+		case r.Sensitive(key):
+			redacted[i] = attribute.String(key, r.replacement)
+		case attr.Value.Type() == attribute.STRING:
+			redacted[i] = attribute.String(key, r.String(attr.Value.AsString()))
+		case attr.Value.Type() == attribute.STRINGSLICE:
+			values := attr.Value.AsStringSlice()
+			for j, value := range values {
+				values[j] = r.String(value)
+			}
+			redacted[i] = attribute.StringSlice(key, values)
+		default:
+			redacted[i] = attr
+		}
+	}
+	return redacted
+}
@@ -0,0 +1,79 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// Writer redacts every zerolog line before it reaches w. Lines that are not
+// JSON objects, such as console output, only get the patterns applied.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return &writer{redactor: r, next: w}
+}
+
+type writer struct {
+	redactor *Redactor
+	next     io.Writer
+}
+
+// Write reports len(p) on success, zerolog treats a short write as an error.
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := w.next.Write(w.redactor.Line(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Line redacts a single JSON log line, keeping the trailing newline.
+func (r *Redactor) Line(p []byte) []byte {
+	if r == nil {
+		return p
+	}
+
+	trimmed := bytes.TrimRight(p, "\n")
+	newline := len(trimmed) < len(p)
+
+	var line map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&line); err != nil {
+		return []byte(r.String(string(p)))
+	}
+
+	redacted, err := json.Marshal(r.value("", line))
+	if err != nil {
+		return []byte(r.String(string(p)))
+	}
+	if newline {
+		redacted = append(redacted, '\n')
+	}
+	return redacted
+}
+
+func (r *Redactor) value(field string, value any) any {
+	if field != "" && r.Sensitive(field) {
+		return r.replacement
+	}
+
+	switch v := value.(type) {
+	case string:
+		return r.String(v)
+	case map[string]any:
+		for key, nested := range v {
+			v[key] = r.value(key, nested)
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			// Elements inherit nothing from the field, only their content counts.
+			v[i] = r.value("", nested)
+		}
+		return v
+	default:
+		return v
+	}
+}
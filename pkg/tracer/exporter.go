@@ -0,0 +1,82 @@
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/shanto-323/backend-scaffold/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// newSpanExporter returns the configured exporter, nil for "none".
+func newSpanExporter(ctx context.Context, cfg *config.OTELConfig) (tracesdk.SpanExporter, error) {
+	switch cfg.Exporter {
+	case config.TraceExporterOTLPHTTP:
+		endpoint := otlptracehttp.WithEndpoint(cfg.TempoEndpoint)
+		if hasScheme(cfg.TempoEndpoint) {
+			endpoint = otlptracehttp.WithEndpointURL(cfg.TempoEndpoint)
+		}
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithInsecure(),
+			otlptracehttp.WithTimeout(cfg.ExportTimeout),
+			endpoint,
+		)
+	case config.TraceExporterOTLPGRPC:
+		endpoint := otlptracegrpc.WithEndpoint(cfg.TempoEndpoint)
+		if hasScheme(cfg.TempoEndpoint) {
+			endpoint = otlptracegrpc.WithEndpointURL(cfg.TempoEndpoint)
+		}
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithInsecure(),
+			otlptracegrpc.WithTimeout(cfg.ExportTimeout),
+			endpoint,
+		)
+	case config.TraceExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case config.TraceExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %s", cfg.Exporter)
+	}
+}
+
+// newMetricExporter follows the trace exporter setting so both signals go to
+// the same place, nil for "none".
+func newMetricExporter(ctx context.Context, cfg *config.OTELConfig) (metricsdk.Exporter, error) {
+	switch cfg.Exporter {
+	case config.TraceExporterOTLPHTTP:
+		endpoint := otlpmetrichttp.WithEndpoint(cfg.TempoEndpoint)
+		if hasScheme(cfg.TempoEndpoint) {
+			endpoint = otlpmetrichttp.WithEndpointURL(cfg.TempoEndpoint)
+		}
+		return otlpmetrichttp.New(ctx,
+			otlpmetrichttp.WithInsecure(),
+			otlpmetrichttp.WithTimeout(cfg.ExportTimeout),
+			endpoint,
+		)
+	case config.TraceExporterOTLPGRPC:
+		endpoint := otlpmetricgrpc.WithEndpoint(cfg.TempoEndpoint)
+		if hasScheme(cfg.TempoEndpoint) {
+			endpoint = otlpmetricgrpc.WithEndpointURL(cfg.TempoEndpoint)
+		}
+		return otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithInsecure(),
+			otlpmetricgrpc.WithTimeout(cfg.ExportTimeout),
+			endpoint,
+		)
+	case config.TraceExporterStdout:
+		return stdoutmetric.New(stdoutmetric.WithWriter(os.Stdout))
+	case config.TraceExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown metric exporter: %s", cfg.Exporter)
+	}
+}
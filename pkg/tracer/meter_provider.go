@@ -0,0 +1,55 @@
+package tracer
+
+import (
+	"context"
+
+	"github.com/shanto-323/backend-scaffold/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// MeterProvider is the metrics sibling of TraceProvider. Services create
+// their instruments from Meter, the exporter stays an implementation detail.
+type MeterProvider struct {
+	meterProvider *metricsdk.MeterProvider
+	Meter         metric.Meter
+}
+
+func NewMeterProvider(ctx context.Context, cfg *config.Config) (*MeterProvider, error) {
+	res, err := newResource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	exp, err := newMetricExporter(ctx, &cfg.Monitor.OTEL)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []metricsdk.Option{metricsdk.WithResource(res)}
+	// With the "none" exporter instruments still work, nothing is pushed.
+	if exp != nil {
+		options = append(options, metricsdk.WithReader(metricsdk.NewPeriodicReader(exp,
+			metricsdk.WithInterval(cfg.Monitor.OTEL.MetricsInterval),
+		)))
+	}
+
+	mp := metricsdk.NewMeterProvider(options...)
+
+	// Instruments created through otel.Meter, such as the panic counter,
+	// are exported by this provider too.
+	otel.SetMeterProvider(mp)
+	return &MeterProvider{
+		meterProvider: mp,
+		Meter:         mp.Meter(cfg.Monitor.ServiceName),
+	}, nil
+}
+
+func (mp *MeterProvider) ForceFlush(ctx context.Context) error {
+	return mp.meterProvider.ForceFlush(ctx)
+}
+
+func (mp *MeterProvider) Shutdown(ctx context.Context) error {
+	return mp.meterProvider.Shutdown(ctx)
+}
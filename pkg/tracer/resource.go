@@ -0,0 +1,28 @@
+package tracer
+
+import (
+	"strings"
+
+	"github.com/shanto-323/backend-scaffold/config"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// newResource describes the service for every signal, traces and metrics
+// must agree on it for backends to correlate them.
+func newResource(config *config.Config) (*resource.Resource, error) {
+	return resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(config.Monitor.ServiceName),
+			semconv.DeploymentEnvironmentName(config.Primary.Env),
+		),
+	)
+}
+
+// hasScheme tells whether the collector endpoint is a full URL or the bare
+// host:port the OTLP exporters expect in WithEndpoint.
+func hasScheme(endpoint string) bool {
+	return strings.Contains(endpoint, "://")
+}
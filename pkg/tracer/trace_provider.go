@@ -3,12 +3,12 @@ package tracer
 import (
 	"context"
 
+	"github.com/rs/zerolog"
 	"github.com/shanto-323/backend-scaffold/config"
+	"github.com/shanto-323/backend-scaffold/pkg/logger"
+	"github.com/shanto-323/backend-scaffold/pkg/redact"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
-	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -18,32 +18,45 @@ type TraceProvider struct {
 }
 
 func New(ctx context.Context, config *config.Config) (*TraceProvider, error) {
-	exp, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithInsecure(),
-		otlptracehttp.WithEndpoint(config.Monitor.OTEL.TempoEndpoint),
-	)
+	cfg := &config.Monitor.OTEL
+
+	exp, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Attributes are scrubbed on the way out, spans in memory stay untouched.
+	redactor, err := redact.New(config.Monitor.Logging.Redaction)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := resource.Merge(
-		resource.Default(),
-		resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceName(config.Monitor.ServiceName),
-			semconv.DeploymentEnvironmentName(config.Primary.Env),
-		),
-	)
+	res, err := newResource(config)
 	if err != nil {
 		return nil, err
 	}
 
-	tp := tracesdk.NewTracerProvider(
-		tracesdk.WithBatcher(exp),
+	options := []tracesdk.TracerProviderOption{
 		tracesdk.WithResource(res),
-	)
+		// A sampled caller keeps its trace whole, new traces are sampled
+		// by ratio.
+		tracesdk.WithSampler(tracesdk.ParentBased(tracesdk.TraceIDRatioBased(*cfg.SampleRatio))),
+	}
+	// Spans are exported in the background, never on the request path. A
+	// full queue drops spans instead of blocking.
+	if exp != nil {
+		options = append(options, tracesdk.WithBatcher(redactor.Exporter(exp),
+			tracesdk.WithBatchTimeout(cfg.BatchTimeout),
+			tracesdk.WithExportTimeout(cfg.ExportTimeout),
+			tracesdk.WithMaxQueueSize(cfg.MaxQueueSize),
+			tracesdk.WithMaxExportBatchSize(cfg.MaxExportBatchSize),
+		))
+	}
+
+	tp := tracesdk.NewTracerProvider(options...)
 
 	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(Propagator())
 	tracer := tp.Tracer(config.Monitor.ServiceName)
 	return &TraceProvider{
 		traceProvider: tp,
@@ -51,6 +64,16 @@ func New(ctx context.Context, config *config.Config) (*TraceProvider, error) {
 	}, nil
 }
 
+// Start begins a span like Tracer.Start. When ctx carries a request logger,
+// the returned context holds a copy with the ids of the new span.
+func (tp *TraceProvider) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx, span := tp.Tracer.Start(ctx, name, opts...)
+	if l := zerolog.Ctx(ctx); l.GetLevel() != zerolog.Disabled {
+		ctx = logger.WithTraceContext(ctx, *l).WithContext(ctx)
+	}
+	return ctx, span
+}
+
 func (tp *TraceProvider) ForceFlush(ctx context.Context) error {
 	return tp.traceProvider.ForceFlush(ctx)
 }
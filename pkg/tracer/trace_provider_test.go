@@ -0,0 +1,61 @@
+package tracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// collectorLatency stands in for the round trip to a nearby collector.
+const collectorLatency = time.Millisecond
+
+type slowExporter struct{}
+
+func (slowExporter) ExportSpans(ctx context.Context, spans []tracesdk.ReadOnlySpan) error {
+	time.Sleep(collectorLatency)
+	return nil
+}
+
+func (slowExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// BenchmarkRequestSpan compares the cost a request pays for its span when
+// every request flushes the batcher, as EnhanceTracing used to, with leaving
+// the export to the batcher.
+func BenchmarkRequestSpan(b *testing.B) {
+	tests := []struct {
+		name  string
+		flush bool
+	}{
+		{name: "flush_per_request", flush: true},
+		{name: "batcher"},
+	}
+
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			tp := tracesdk.NewTracerProvider(tracesdk.WithBatcher(slowExporter{},
+				tracesdk.WithBatchTimeout(5*time.Second),
+				tracesdk.WithMaxQueueSize(2048),
+				tracesdk.WithMaxExportBatchSize(512),
+			))
+			defer func() { _ = tp.Shutdown(context.Background()) }()
+			tracer := tp.Tracer("benchmark")
+
+			ctx := context.Background()
+			b.ReportAllocs()
+			for b.Loop() {
+				_, span := tracer.Start(ctx, "GET /api/v1/student")
+				span.End()
+
+				if tt.flush {
+					if err := tp.ForceFlush(ctx); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
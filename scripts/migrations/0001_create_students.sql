@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS students (
+    id         UUID PRIMARY KEY,
+    name       TEXT NOT NULL,
+    roll       INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
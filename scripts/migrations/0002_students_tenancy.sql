@@ -0,0 +1,15 @@
+-- Every school is a tenant. The application sets app.tenant_id on each
+-- connection it hands out, these policies keep tenants from seeing each other.
+ALTER TABLE students
+    ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT current_setting('app.tenant_id', true);
+
+CREATE INDEX IF NOT EXISTS students_tenant_id_idx ON students (tenant_id);
+
+ALTER TABLE students ENABLE ROW LEVEL SECURITY;
+-- The application connects as the table owner, which bypasses RLS unless forced.
+ALTER TABLE students FORCE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS students_tenant_isolation ON students;
+CREATE POLICY students_tenant_isolation ON students
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
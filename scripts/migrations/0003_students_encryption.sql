@@ -0,0 +1,21 @@
+-- Contact details are encrypted by the application with AES-GCM. key_id names
+-- the keyring key of the row, the *_bidx columns are keyed hashes (blind
+-- indexes) used for equality search.
+ALTER TABLE students
+    ADD COLUMN IF NOT EXISTS phone_enc             BYTEA,
+    ADD COLUMN IF NOT EXISTS address_enc           BYTEA,
+    ADD COLUMN IF NOT EXISTS guardian_contact_enc  BYTEA,
+    ADD COLUMN IF NOT EXISTS key_id                TEXT,
+    ADD COLUMN IF NOT EXISTS phone_bidx            TEXT,
+    ADD COLUMN IF NOT EXISTS guardian_contact_bidx TEXT;
+
+CREATE INDEX IF NOT EXISTS students_phone_bidx_idx ON students (tenant_id, phone_bidx);
+CREATE INDEX IF NOT EXISTS students_guardian_contact_bidx_idx ON students (tenant_id, guardian_contact_bidx);
+CREATE INDEX IF NOT EXISTS students_key_id_idx ON students (key_id);
+
+-- The re-encryption job works across tenants. It enables this policy for its
+-- own transaction only, with set_config('app.maintenance', 'on', true).
+DROP POLICY IF EXISTS students_maintenance ON students;
+CREATE POLICY students_maintenance ON students
+    USING (current_setting('app.maintenance', true) = 'on')
+    WITH CHECK (current_setting('app.maintenance', true) = 'on');
@@ -0,0 +1,18 @@
+-- Verified webhook deliveries with their raw payload, kept for reprocessing.
+-- Senders are not tenants, so the table has no row level security.
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id           UUID PRIMARY KEY,
+    source       TEXT NOT NULL,
+    event_type   TEXT NOT NULL,
+    delivery_id  TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    payload      BYTEA NOT NULL,
+    status       TEXT NOT NULL,
+    error        TEXT,
+    attempts     INTEGER NOT NULL DEFAULT 0,
+    received_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    processed_at TIMESTAMPTZ,
+    UNIQUE (source, delivery_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_events_status_idx ON webhook_events (status, received_at);
@@ -0,0 +1,4 @@
+-- W3C trace context of the request that delivered the event, reprocessing
+-- links its span to the original trace.
+ALTER TABLE webhook_events
+    ADD COLUMN IF NOT EXISTS trace_context JSONB NOT NULL DEFAULT '{}';